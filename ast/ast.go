@@ -2,7 +2,10 @@ package ast
 
 import (
 	"bytes"
+	"fmt"
+	"math/big"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/Jitesh117/brainrotLang-interpreter/token"
 )
@@ -10,6 +13,7 @@ import (
 type Node interface {
 	TokenLiteral() string
 	String() string
+	Pos() token.Position
 }
 type Statement interface {
 	Node
@@ -33,6 +37,13 @@ func (p *Program) TokenLiteral() string {
 	}
 }
 
+func (p *Program) Pos() token.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+	return token.Position{}
+}
+
 func (p *Program) String() string {
 	var out bytes.Buffer
 
@@ -51,6 +62,7 @@ type YeetStatement struct {
 
 func (ls *YeetStatement) StatementNode()       {}
 func (ls *YeetStatement) TokenLiteral() string { return ls.Token.Literal }
+func (ls *YeetStatement) Pos() token.Position  { return ls.Token.Pos }
 
 func (ls *YeetStatement) String() string {
 	var out bytes.Buffer
@@ -75,6 +87,7 @@ type Identifier struct {
 
 func (i *Identifier) expressionNode()      {}
 func (i *Identifier) TokenLiteral() string { return i.Token.Literal }
+func (i *Identifier) Pos() token.Position  { return i.Token.Pos }
 func (i *Identifier) String() string       { return i.Value }
 
 type SlayStatement struct {
@@ -84,6 +97,7 @@ type SlayStatement struct {
 
 func (rs *SlayStatement) StatementNode()       {}
 func (rs *SlayStatement) TokenLiteral() string { return rs.Token.Literal }
+func (rs *SlayStatement) Pos() token.Position  { return rs.Token.Pos }
 
 func (rs *SlayStatement) String() string {
 	var out bytes.Buffer
@@ -106,6 +120,7 @@ type ExpressionStatement struct {
 
 func (es *ExpressionStatement) StatementNode()       {}
 func (es *ExpressionStatement) TokenLiteral() string { return es.Token.Literal }
+func (es *ExpressionStatement) Pos() token.Position  { return es.Token.Pos }
 
 func (es *ExpressionStatement) String() string {
 	if es.Expression != nil {
@@ -117,12 +132,24 @@ func (es *ExpressionStatement) String() string {
 type IntegerLiteral struct {
 	Token token.Token
 	Value int64
+	Big   *big.Int // set instead of Value when the literal doesn't fit in an int64
 }
 
 func (il *IntegerLiteral) expressionNode()      {}
 func (il *IntegerLiteral) TokenLiteral() string { return il.Token.Literal }
+func (il *IntegerLiteral) Pos() token.Position  { return il.Token.Pos }
 func (il *IntegerLiteral) String() string       { return il.Token.Literal }
 
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode()      {}
+func (fl *FloatLiteral) TokenLiteral() string { return fl.Token.Literal }
+func (fl *FloatLiteral) Pos() token.Position  { return fl.Token.Pos }
+func (fl *FloatLiteral) String() string       { return fl.Token.Literal }
+
 type PrefixExpression struct {
 	Token    token.Token
 	Operator string
@@ -131,6 +158,7 @@ type PrefixExpression struct {
 
 func (pe *PrefixExpression) expressionNode()      {}
 func (pe *PrefixExpression) TokenLiteral() string { return pe.Token.Literal }
+func (pe *PrefixExpression) Pos() token.Position  { return pe.Token.Pos }
 func (pe *PrefixExpression) String() string {
 	var out bytes.Buffer
 
@@ -151,6 +179,7 @@ type InfixExpression struct {
 
 func (oe *InfixExpression) expressionNode()      {}
 func (oe *InfixExpression) TokenLiteral() string { return oe.Token.Literal }
+func (oe *InfixExpression) Pos() token.Position  { return oe.Token.Pos }
 func (oe *InfixExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
@@ -161,6 +190,39 @@ func (oe *InfixExpression) String() string {
 	return out.String()
 }
 
+type AssignExpression struct {
+	Token    token.Token // the '=' token, or an operator like '+='
+	Target   Expression  // an *Identifier or *IndexExpression
+	Operator string
+	Value    Expression
+}
+
+func (ae *AssignExpression) expressionNode()      {}
+func (ae *AssignExpression) TokenLiteral() string { return ae.Token.Literal }
+func (ae *AssignExpression) Pos() token.Position  { return ae.Token.Pos }
+func (ae *AssignExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(ae.Target.String())
+	out.WriteString(" " + ae.Operator + " ")
+	out.WriteString(ae.Value.String())
+	out.WriteString(")")
+	return out.String()
+}
+
+type PostfixExpression struct {
+	Token    token.Token // the '++' or '--' token
+	Left     Expression  // an *Identifier or *IndexExpression
+	Operator string
+}
+
+func (pe *PostfixExpression) expressionNode()      {}
+func (pe *PostfixExpression) TokenLiteral() string { return pe.Token.Literal }
+func (pe *PostfixExpression) Pos() token.Position  { return pe.Token.Pos }
+func (pe *PostfixExpression) String() string {
+	return "(" + pe.Left.String() + pe.Operator + ")"
+}
+
 type Boolean struct {
 	Token token.Token
 	Value bool
@@ -168,6 +230,7 @@ type Boolean struct {
 
 func (b *Boolean) expressionNode()      {}
 func (b *Boolean) TokenLiteral() string { return b.Token.Literal }
+func (b *Boolean) Pos() token.Position  { return b.Token.Pos }
 func (b *Boolean) String() string       { return b.Token.Literal }
 
 type FrExpression struct {
@@ -179,6 +242,7 @@ type FrExpression struct {
 
 func (ie *FrExpression) expressionNode()      {}
 func (ie *FrExpression) TokenLiteral() string { return ie.Token.Literal }
+func (ie *FrExpression) Pos() token.Position  { return ie.Token.Pos }
 func (ie *FrExpression) String() string {
 	var out bytes.Buffer
 
@@ -203,6 +267,7 @@ type BlockStatement struct {
 
 func (bs *BlockStatement) StatementNode()       {}
 func (bs *BlockStatement) TokenLiteral() string { return bs.Token.Literal }
+func (bs *BlockStatement) Pos() token.Position  { return bs.Token.Pos }
 func (bs *BlockStatement) String() string {
 	var out bytes.Buffer
 	for _, s := range bs.Statements {
@@ -213,29 +278,139 @@ func (bs *BlockStatement) String() string {
 
 type VibeLiteral struct {
 	Token      token.Token // the 'vibe' token
+	Name       string      // the name it is bound to, if any
 	Parameters []*Identifier
+	Defaults   []Expression // parallel to Parameters, nil where there is no default
+	Rest       *Identifier  // collects surplus args, nil if there is no ...rest
 	Body       *BlockStatement
 }
 
 func (fl *VibeLiteral) expressionNode()      {}
 func (fl *VibeLiteral) TokenLiteral() string { return fl.Token.Literal }
+func (fl *VibeLiteral) Pos() token.Position  { return fl.Token.Pos }
 func (fl *VibeLiteral) String() string {
 	var out bytes.Buffer
 
+	out.WriteString(fl.TokenLiteral())
+	out.WriteString("(")
+	out.WriteString(ParameterList(fl.Parameters, fl.Defaults, fl.Rest))
+	out.WriteString(") ")
+	out.WriteString(fl.Body.String())
+
+	return out.String()
+}
+
+// ParameterList renders vibe parameters as "a, b = 10, ...rest". Defaults
+// runs alongside parameters, with nil for the ones without a default, and
+// rest may be nil.
+func ParameterList(parameters []*Identifier, defaults []Expression, rest *Identifier) string {
 	params := []string{}
-	for _, p := range fl.Parameters {
-		params = append(params, p.String())
+	for i, p := range parameters {
+		if i < len(defaults) && defaults[i] != nil {
+			params = append(params, p.String()+" = "+defaults[i].String())
+		} else {
+			params = append(params, p.String())
+		}
 	}
+	if rest != nil {
+		params = append(params, "..."+rest.String())
+	}
+	return strings.Join(params, ", ")
+}
 
-	out.WriteString(fl.TokenLiteral())
-	out.WriteString("{}")
-	out.WriteString(strings.Join(params, ", "))
-	out.WriteString("} ")
-	out.WriteString(fl.Body.String())
+type VibeStatement struct {
+	Token token.Token // the 'vibe' token
+	Name  *Identifier
+	Vibe  *VibeLiteral
+}
+
+func (vs *VibeStatement) StatementNode()       {}
+func (vs *VibeStatement) TokenLiteral() string { return vs.Token.Literal }
+func (vs *VibeStatement) Pos() token.Position  { return vs.Token.Pos }
+func (vs *VibeStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(vs.TokenLiteral() + " ")
+	out.WriteString(vs.Name.String())
+	out.WriteString("(")
+	out.WriteString(ParameterList(vs.Vibe.Parameters, vs.Vibe.Defaults, vs.Vibe.Rest))
+	out.WriteString(") ")
+	out.WriteString(vs.Vibe.Body.String())
 
 	return out.String()
 }
 
+type GrindStatement struct {
+	Token     token.Token // the 'grind' token
+	Condition Expression
+	Body      *BlockStatement
+}
+
+func (gs *GrindStatement) StatementNode()       {}
+func (gs *GrindStatement) TokenLiteral() string { return gs.Token.Literal }
+func (gs *GrindStatement) Pos() token.Position  { return gs.Token.Pos }
+func (gs *GrindStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(gs.TokenLiteral())
+	out.WriteString("(")
+	out.WriteString(gs.Condition.String())
+	out.WriteString(") ")
+	out.WriteString(gs.Body.String())
+
+	return out.String()
+}
+
+// GrindInStatement loops over the elements of an array, the characters of a
+// string or the pairs of a hash. With a single name it is bound to the
+// element, the character or the hash key; with two, Key is bound to the
+// index or hash key and Value to the element, character or hash value.
+type GrindInStatement struct {
+	Token    token.Token // the 'grind' token
+	Key      *Identifier // nil unless two names are given
+	Value    *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+func (gs *GrindInStatement) StatementNode()       {}
+func (gs *GrindInStatement) TokenLiteral() string { return gs.Token.Literal }
+func (gs *GrindInStatement) Pos() token.Position  { return gs.Token.Pos }
+func (gs *GrindInStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(gs.TokenLiteral())
+	out.WriteString("(")
+	if gs.Key != nil {
+		out.WriteString(gs.Key.String() + ", ")
+	}
+	out.WriteString(gs.Value.String())
+	out.WriteString(" in ")
+	out.WriteString(gs.Iterable.String())
+	out.WriteString(") ")
+	out.WriteString(gs.Body.String())
+
+	return out.String()
+}
+
+type GhostStatement struct {
+	Token token.Token // the 'ghost' token
+}
+
+func (gs *GhostStatement) StatementNode()       {}
+func (gs *GhostStatement) TokenLiteral() string { return gs.Token.Literal }
+func (gs *GhostStatement) Pos() token.Position  { return gs.Token.Pos }
+func (gs *GhostStatement) String() string       { return gs.TokenLiteral() + ";" }
+
+type NvmStatement struct {
+	Token token.Token // the 'nvm' token
+}
+
+func (ns *NvmStatement) StatementNode()       {}
+func (ns *NvmStatement) TokenLiteral() string { return ns.Token.Literal }
+func (ns *NvmStatement) Pos() token.Position  { return ns.Token.Pos }
+func (ns *NvmStatement) String() string       { return ns.TokenLiteral() + ";" }
+
 type CallExpression struct {
 	Token     token.Token // '(' token
 	Function  Expression  // Identifier of FunctionLiteral ig
@@ -244,6 +419,7 @@ type CallExpression struct {
 
 func (ce *CallExpression) expressionNode()      {}
 func (ce *CallExpression) TokenLiteral() string { return ce.Token.Literal }
+func (ce *CallExpression) Pos() token.Position  { return ce.Token.Pos }
 func (ce *CallExpression) String() string {
 	var out bytes.Buffer
 
@@ -267,7 +443,73 @@ type StringLiteral struct {
 
 func (sl *StringLiteral) expressionNode()      {}
 func (sl *StringLiteral) TokenLiteral() string { return sl.Token.Literal }
-func (sl *StringLiteral) String() string       { return sl.Token.Literal }
+func (sl *StringLiteral) Pos() token.Position  { return sl.Token.Pos }
+func (sl *StringLiteral) String() string {
+	// Multi-line text reads best as a raw string, if it can be one
+	if strings.Contains(sl.Value, "\n") && canBeRaw(sl.Value) {
+		return "`" + sl.Value + "`"
+	}
+	return `"` + escapeString(sl.Value, false) + `"`
+}
+
+// canBeRaw reports whether the lexer would read s back unchanged from between
+// backticks. It drops \r, stops at NUL and can't keep invalid UTF-8.
+func canBeRaw(s string) bool {
+	return !strings.ContainsAny(s, "`\r\x00") && utf8.ValidString(s)
+}
+
+// InterpolatedString is an f-string. Its parts are StringLiterals for the
+// text between placeholders and the expressions inside the placeholders.
+type InterpolatedString struct {
+	Token token.Token // the FSTRING_HEAD token
+	Parts []Expression
+}
+
+func (is *InterpolatedString) expressionNode()      {}
+func (is *InterpolatedString) TokenLiteral() string { return is.Token.Literal }
+func (is *InterpolatedString) Pos() token.Position  { return is.Token.Pos }
+func (is *InterpolatedString) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(`f"`)
+	for _, part := range is.Parts {
+		if text, ok := part.(*StringLiteral); ok {
+			out.WriteString(escapeString(text.Value, true))
+		} else {
+			out.WriteString("{" + part.String() + "}")
+		}
+	}
+	out.WriteString(`"`)
+
+	return out.String()
+}
+
+// escapeString escapes s to go between the quotes of a string literal, and
+// also escapes braces for an f-string.
+func escapeString(s string, fstring bool) string {
+	var out strings.Builder
+	for _, ch := range s {
+		switch {
+		case ch == '"' || ch == '\\':
+			out.WriteString(`\` + string(ch))
+		case ch == '\n':
+			out.WriteString(`\n`)
+		case ch == '\t':
+			out.WriteString(`\t`)
+		case ch == '\r':
+			out.WriteString(`\r`)
+		case ch == 0:
+			out.WriteString(`\0`)
+		case ch < ' ' || ch == 0x7f:
+			out.WriteString(fmt.Sprintf(`\u{%X}`, ch))
+		case fstring && (ch == '{' || ch == '}'):
+			out.WriteString(`\` + string(ch))
+		default:
+			out.WriteRune(ch)
+		}
+	}
+	return out.String()
+}
 
 type ArrayLiteral struct {
 	Token    token.Token // the '[' token  that is
@@ -276,6 +518,7 @@ type ArrayLiteral struct {
 
 func (al *ArrayLiteral) expressionNode()      {}
 func (al *ArrayLiteral) TokenLiteral() string { return al.Token.Literal }
+func (al *ArrayLiteral) Pos() token.Position  { return al.Token.Pos }
 func (al *ArrayLiteral) String() string {
 	var out bytes.Buffer
 
@@ -298,6 +541,7 @@ type IndexExpression struct {
 
 func (ie *IndexExpression) expressionNode()      {}
 func (ie *IndexExpression) TokenLiteral() string { return ie.Token.Literal }
+func (ie *IndexExpression) Pos() token.Position  { return ie.Token.Pos }
 func (ie *IndexExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
@@ -315,6 +559,7 @@ type HashLiteral struct {
 
 func (hl *HashLiteral) expressionNode()      {}
 func (hl *HashLiteral) TokenLiteral() string { return hl.Token.Literal }
+func (hl *HashLiteral) Pos() token.Position  { return hl.Token.Pos }
 func (hl *HashLiteral) String() string {
 	var out bytes.Buffer
 
@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/Jitesh117/brainrotLang-interpreter/token"
@@ -26,3 +27,54 @@ func TestString(t *testing.T) {
 		t.Errorf("program.String() wrong, got=%q", program.String())
 	}
 }
+
+func TestFprint(t *testing.T) {
+	program := &Program{
+		Statements: []Statement{
+			&YeetStatement{
+				Token: token.Token{Type: token.LET, Literal: "yeet", Pos: token.Position{Line: 1, Column: 1}},
+				Name: &Identifier{
+					Token: token.Token{Type: token.IDENT, Literal: "myVar", Pos: token.Position{Line: 1, Column: 6}},
+					Value: "myVar",
+				},
+				Value: &IntegerLiteral{
+					Token: token.Token{Type: token.INT, Literal: "5", Pos: token.Position{Line: 1, Column: 14}},
+					Value: 5,
+				},
+			},
+		},
+	}
+
+	expected := `Program 1:1
+  Statements[0]: YeetStatement 1:1
+    Name: Identifier 1:6 Value="myVar"
+    Value: IntegerLiteral 1:14 Value=5
+`
+	var out bytes.Buffer
+	Fprint(&out, program)
+	if out.String() != expected {
+		t.Errorf("Fprint wrong.\nexpected=\n%s\ngot=\n%s", expected, out.String())
+	}
+}
+
+func TestStringLiteralString(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"hello", `"hello"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\dir`, `"C:\\dir"`},
+		{"tab\there", `"tab\there"`},
+		{"line one\nline two", "`line one\nline two`"},
+		{"has `tick`\nand a newline", `"has ` + "`tick`" + `\nand a newline"`},
+		{"bell\a", `"bell\u{7}"`},
+	}
+
+	for _, tt := range tests {
+		lit := &StringLiteral{Token: token.Token{Type: token.STRING, Literal: tt.value}, Value: tt.value}
+		if lit.String() != tt.expected {
+			t.Errorf("String() wrong for %q. expected=%s, got=%s", tt.value, tt.expected, lit.String())
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package ast
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Fprint writes node to w as an indented tree, one node per line with its
+// type, position and scalar fields, e.g.
+//
+//	Program 1:1
+//	  Statements[0]: YeetStatement 1:1
+//	    Name: Identifier 1:6 Value="x"
+//	    Value: IntegerLiteral 1:10 Value=5
+func Fprint(w io.Writer, node Node) {
+	printNode(w, 0, "", node)
+}
+
+var nodeType = reflect.TypeOf((*Node)(nil)).Elem()
+
+func printNode(w io.Writer, depth int, label string, node Node) {
+	indent := strings.Repeat("  ", depth)
+	if label != "" {
+		indent += label + ": "
+	}
+
+	v := reflect.ValueOf(node)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			fmt.Fprintln(w, indent+"<nil>")
+			return
+		}
+		v = v.Elem()
+	}
+
+	// Scalar fields go on the node's own line, child nodes on the lines below.
+	var attrs []string
+	type child struct {
+		label string
+		node  Node
+	}
+	var children []child
+
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Name
+		field := v.Field(i)
+		if name == "Token" {
+			continue
+		}
+
+		switch {
+		case field.Type().Implements(nodeType):
+			if !field.IsNil() {
+				children = append(children, child{name, field.Interface().(Node)})
+			}
+		case field.Kind() == reflect.Slice && field.Type().Elem().Implements(nodeType):
+			for j := 0; j < field.Len(); j++ {
+				if el := field.Index(j); !el.IsNil() {
+					children = append(children, child{fmt.Sprintf("%s[%d]", name, j), el.Interface().(Node)})
+				}
+			}
+		case field.Kind() == reflect.Map && field.Type().Key().Implements(nodeType):
+			// Map order is random, so sort the pairs by their source text.
+			keys := field.MapKeys()
+			sort.Slice(keys, func(a, b int) bool {
+				return keys[a].Interface().(Node).String() < keys[b].Interface().(Node).String()
+			})
+			for _, key := range keys {
+				children = append(children, child{name + " key", key.Interface().(Node)})
+				if val := field.MapIndex(key); !val.IsNil() {
+					children = append(children, child{name + " value", val.Interface().(Node)})
+				}
+			}
+		case field.Kind() == reflect.Pointer && field.IsNil():
+			// nothing to show
+		case field.Kind() == reflect.String:
+			attrs = append(attrs, fmt.Sprintf("%s=%q", name, field.String()))
+		default:
+			attrs = append(attrs, fmt.Sprintf("%s=%v", name, field.Interface()))
+		}
+	}
+
+	line := indent + v.Type().Name() + " " + node.Pos().String()
+	if len(attrs) > 0 {
+		line += " " + strings.Join(attrs, " ")
+	}
+	fmt.Fprintln(w, line)
+
+	for _, c := range children {
+		printNode(w, depth+1, c.label, c.node)
+	}
+}
@@ -0,0 +1,170 @@
+package diagnostic
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Jitesh117/brainrotLang-interpreter/token"
+)
+
+type Severity int
+
+const (
+	Error Severity = iota
+	Warning
+	Note
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	case Note:
+		return "note"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Code identifies the kind of problem, so tools can match on it instead of
+// on the message text.
+type Code string
+
+const (
+	UnexpectedToken Code = "E0001" // expected one token, found another
+	NoPrefixParseFn Code = "E0002" // token cannot start an expression
+	InvalidInteger  Code = "E0003" // integer literal out of range or malformed
+
+	UnterminatedComment Code = "E0004" // block comment without a closing */
+
+	LoopControlOutsideLoop Code = "E0005" // ghost or nvm outside a grind loop
+	InvalidAssignment      Code = "E0006" // left of = is not a name or index expression
+	InvalidParameter       Code = "E0007" // required parameter after one with a default
+
+	InvalidFloat    Code = "E0008" // float literal out of range
+	MalformedNumber Code = "E0009" // numeric literal the lexer can't read
+
+	UnterminatedString Code = "E0010" // string literal without a closing quote
+	InvalidEscape      Code = "E0011" // unknown or malformed escape sequence in a string
+
+	EmptyPlaceholder Code = "E0012" // f-string placeholder with no expression in it
+)
+
+// Span is the region of source a diagnostic refers to. End is exclusive.
+type Span struct {
+	Start token.Position
+	End   token.Position
+}
+
+// SpanOf returns the span covered by tok in the source. Tokens from the lexer
+// know where they end; for others it is worked out from the literal.
+func SpanOf(tok token.Token) Span {
+	if tok.End.IsValid() {
+		return Span{Start: tok.Pos, End: tok.End}
+	}
+	// Offsets count bytes but columns count characters
+	bytes, chars := len(tok.Literal), utf8.RuneCountInString(tok.Literal)
+	if tok.Type == token.STRING {
+		bytes, chars = bytes+2, chars+2 // the quotes are not part of the literal
+	}
+	end := tok.Pos
+	end.Offset += bytes
+	end.Column += chars
+	return Span{Start: tok.Pos, End: end}
+}
+
+type Diagnostic struct {
+	Severity Severity
+	Code     Code
+	Span     Span
+	Expected []token.TokenType // token types that would have been accepted, if any
+	Actual   token.TokenType   // token type that was found, if any
+	Message  string
+}
+
+// String formats the diagnostic on a single line as "position: message".
+func (d Diagnostic) String() string {
+	return d.Span.Start.String() + ": " + d.Message
+}
+
+// Render writes d to w followed by the offending line of source with the
+// span underlined by carets:
+//
+//	error[E0001]: expected next token to be =, got INT instead
+//	 --> main.br:1:8
+//	  |
+//	1 | yeet x 5;
+//	  |        ^
+func Render(w io.Writer, source string, d Diagnostic) {
+	lines := strings.Split(source, "\n")
+	RenderFrom(w, func(n int) (string, bool) {
+		if n > len(lines) {
+			return "", false
+		}
+		return lines[n-1], true
+	}, d)
+}
+
+// RenderFrom is like Render, but asks lineAt for line n of the source instead
+// of needing all of it. When lineAt doesn't have the line, only the position
+// is shown.
+func RenderFrom(w io.Writer, lineAt func(n int) (string, bool), d Diagnostic) {
+	fmt.Fprintf(w, "%s[%s]: %s\n", d.Severity, d.Code, d.Message)
+
+	start := d.Span.Start
+	if !start.IsValid() {
+		return
+	}
+
+	line, ok := lineAt(start.Line)
+	if !ok {
+		fmt.Fprintf(w, " --> %s\n", start)
+		return
+	}
+	line = strings.TrimRight(line, "\r")
+
+	gutter := fmt.Sprintf("%d", start.Line)
+	pad := strings.Repeat(" ", len(gutter))
+	fmt.Fprintf(w, "%s--> %s\n", pad, start)
+	fmt.Fprintf(w, "%s |\n", pad)
+	fmt.Fprintf(w, "%s | %s\n", gutter, line)
+	fmt.Fprintf(w, "%s | %s%s\n", pad, indentTo(line, start.Column), strings.Repeat("^", underlineWidth(d.Span, line)))
+}
+
+// indentTo returns the whitespace that lines a caret up under column col of
+// line, keeping tabs so the caret stays aligned however tabs are displayed.
+func indentTo(line string, col int) string {
+	chars := []rune(line)
+	var out strings.Builder
+	for i := 0; i < col-1; i++ {
+		if i < len(chars) && chars[i] == '\t' {
+			out.WriteByte('\t')
+		} else {
+			out.WriteByte(' ')
+		}
+	}
+	return out.String()
+}
+
+// underlineWidth returns how many carets to draw for span on line. Spans that
+// run past the end of the line are clipped to it.
+func underlineWidth(span Span, line string) int {
+	length := utf8.RuneCountInString(line)
+	width := 1
+	if span.End.Line == span.Start.Line && span.End.Column > span.Start.Column {
+		width = span.End.Column - span.Start.Column
+	} else if span.End.Line > span.Start.Line {
+		width = length - span.Start.Column + 1
+	}
+	if max := length - span.Start.Column + 1; width > max && max > 0 {
+		width = max
+	}
+	if width < 1 {
+		width = 1
+	}
+	return width
+}
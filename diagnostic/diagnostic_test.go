@@ -0,0 +1,81 @@
+package diagnostic
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Jitesh117/brainrotLang-interpreter/token"
+)
+
+func TestRender(t *testing.T) {
+	source := "yeet x = 5;\nyeet y = add(x, 2;\n"
+	d := Diagnostic{
+		Severity: Error,
+		Code:     UnexpectedToken,
+		Span: Span{
+			Start: token.Position{Filename: "main.br", Line: 2, Column: 10},
+			End:   token.Position{Filename: "main.br", Line: 2, Column: 13},
+		},
+		Message: "something went wrong",
+	}
+
+	expected := `error[E0001]: something went wrong
+ --> main.br:2:10
+  |
+2 | yeet y = add(x, 2;
+  |          ^^^
+`
+	var out bytes.Buffer
+	Render(&out, source, d)
+	if out.String() != expected {
+		t.Errorf("Render wrong.\nexpected=\n%s\ngot=\n%s", expected, out.String())
+	}
+}
+
+func TestSpanOf(t *testing.T) {
+	tests := []struct {
+		tok           token.Token
+		expectedWidth int
+	}{
+		{token.Token{Type: token.IDENT, Literal: "foobar", Pos: token.Position{Line: 1, Column: 3}}, 6},
+		{token.Token{Type: token.STRING, Literal: "hi", Pos: token.Position{Line: 1, Column: 3}}, 4},
+		{token.Token{Type: token.EOF, Literal: "", Pos: token.Position{Line: 1, Column: 3}}, 0},
+		{token.Token{Type: token.IDENT, Literal: "café", Pos: token.Position{Line: 1, Column: 3}}, 4},
+		{token.Token{Type: token.STRING, Literal: "💀💀", Pos: token.Position{Line: 1, Column: 3}}, 4},
+		{token.Token{Type: token.STRING, Literal: "💀\n",
+			Pos: token.Position{Line: 1, Column: 3}, End: token.Position{Line: 1, Column: 17}}, 14},
+	}
+
+	for _, tt := range tests {
+		span := SpanOf(tt.tok)
+		if width := span.End.Column - span.Start.Column; width != tt.expectedWidth {
+			t.Errorf("span of %q has wrong width. expected=%d, got=%d",
+				tt.tok.Literal, tt.expectedWidth, width)
+		}
+	}
+}
+
+func TestRenderUnicodeLine(t *testing.T) {
+	source := "yeet café = \"💀\" + ;"
+	d := Diagnostic{
+		Severity: Error,
+		Code:     NoPrefixParseFn,
+		Span: Span{
+			Start: token.Position{Line: 1, Column: 20},
+			End:   token.Position{Line: 1, Column: 21},
+		},
+		Message: "no prefix parse function for ; found",
+	}
+
+	expected := `error[E0002]: no prefix parse function for ; found
+ --> 1:20
+  |
+1 | yeet café = "💀" + ;
+  |                    ^
+`
+	var out bytes.Buffer
+	Render(&out, source, d)
+	if out.String() != expected {
+		t.Errorf("Render wrong.\nexpected=\n%s\ngot=\n%s", expected, out.String())
+	}
+}
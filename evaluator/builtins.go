@@ -1,6 +1,14 @@
 package evaluator
 
-import "github.com/Jitesh117/brainrotLang-interpreter/object"
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Jitesh117/brainrotLang-interpreter/object"
+)
 
 var builtins = map[string]*object.Builtin{
 	"rizzLevel": {
@@ -10,12 +18,59 @@ var builtins = map[string]*object.Builtin{
 			}
 			switch arg := args[0].(type) {
 			case *object.String:
-				return &object.Integer{Value: int64(len(arg.Value))}
+				return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
 			default:
 				return newError("argument to `rizzLevel` not supported, got %s", args[0].Type())
 			}
 		},
 	},
+	"float": {
+		Vb: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1", len(args))
+			}
+			switch arg := args[0].(type) {
+			case *object.Float:
+				return arg
+			case *object.Integer, *object.BigInteger:
+				return toFloat(arg)
+			case *object.String:
+				value, err := strconv.ParseFloat(strings.TrimSpace(arg.Value), 64)
+				if err != nil {
+					return newError("can't turn %q into a FLOAT, no cap", arg.Value)
+				}
+				return &object.Float{Value: value}
+			default:
+				return newError("argument to `float` not supported, got %s", args[0].Type())
+			}
+		},
+	},
+	"int": {
+		Vb: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1", len(args))
+			}
+			switch arg := args[0].(type) {
+			case *object.Integer, *object.BigInteger:
+				return arg
+			case *object.Float:
+				// Truncates toward zero
+				if math.IsNaN(arg.Value) || math.IsInf(arg.Value, 0) {
+					return newError("can't turn %s into an INTEGER, no cap", arg.Inspect())
+				}
+				value, _ := big.NewFloat(arg.Value).Int(nil)
+				return normalizeInteger(value)
+			case *object.String:
+				value, ok := new(big.Int).SetString(strings.TrimSpace(arg.Value), 10)
+				if !ok {
+					return newError("can't turn %q into an INTEGER, no cap", arg.Value)
+				}
+				return normalizeInteger(value)
+			default:
+				return newError("argument to `int` not supported, got %s", args[0].Type())
+			}
+		},
+	},
 	"first": {
 		Vb: func(args ...object.Object) object.Object {
 			if len(args) != 1 {
@@ -2,6 +2,10 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
 
 	"github.com/Jitesh117/brainrotLang-interpreter/ast"
 	"github.com/Jitesh117/brainrotLang-interpreter/object"
@@ -11,9 +15,21 @@ var (
 	BASED = &object.Boolean{Value: true}
 	CAP   = &object.Boolean{Value: false}
 	NULL  = &object.Null{}
+	GHOST = &object.Ghost{}
+	NVM   = &object.Nvm{}
 )
 
+// Eval evaluates node in env. Errors are stamped with the position of the
+// innermost node that produced them.
 func Eval(node ast.Node, env *object.Environment) object.Object {
+	result := evalNode(node, env)
+	if err, ok := result.(*object.Error); ok && !err.Pos.IsValid() {
+		err.Pos = node.Pos()
+	}
+	return result
+}
+
+func evalNode(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 	// Statements
 	case *ast.Program:
@@ -22,7 +38,12 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return Eval(node.Expression, env)
 	// Expressions
 	case *ast.IntegerLiteral:
+		if node.Big != nil {
+			return &object.BigInteger{Value: node.Big}
+		}
 		return &object.Integer{Value: node.Value}
+	case *ast.FloatLiteral:
+		return &object.Float{Value: node.Value}
 	case *ast.Boolean:
 		return nativeBoolToBooleanObject(node.Value)
 	case *ast.HashLiteral:
@@ -32,8 +53,11 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		if isError(right) {
 			return right
 		}
-		return evalPrefixExpression(node.Operator, right)
+		return evalPrefixExpression(node.Operator, right, env.Checked())
 	case *ast.InfixExpression:
+		if node.Operator == "&&" || node.Operator == "||" {
+			return evalLogicalExpression(node, env)
+		}
 		left := Eval(node.Left, env)
 		right := Eval(node.Right, env)
 		if isError(left) {
@@ -42,11 +66,19 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		if isError(right) {
 			return right
 		}
-		return evalInfixExpression(node.Operator, left, right)
+		return evalInfixExpression(node.Operator, left, right, env.Checked())
 	case *ast.BlockStatement:
 		return evalBlockStatement(node, env)
 	case *ast.FrExpression:
 		return evalFrExpression(node, env)
+	case *ast.GrindStatement:
+		return evalGrindStatement(node, env)
+	case *ast.GrindInStatement:
+		return evalGrindInStatement(node, env)
+	case *ast.GhostStatement:
+		return GHOST
+	case *ast.NvmStatement:
+		return NVM
 	case *ast.SlayStatement:
 		val := Eval(node.SlayValue, env)
 		if isError(val) {
@@ -59,12 +91,28 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return val
 		}
 		env.Set(node.Name.Value, val)
+	case *ast.VibeStatement:
+		// The vibe closes over the environment it is bound in, so it can
+		// call itself by name
+		vb := &object.Vibe{
+			Name:       node.Vibe.Name,
+			Parameters: node.Vibe.Parameters,
+			Defaults:   node.Vibe.Defaults,
+			Rest:       node.Vibe.Rest,
+			Env:        env,
+			Body:       node.Vibe.Body,
+		}
+		env.Set(node.Name.Value, vb)
+	case *ast.AssignExpression:
+		return evalAssignExpression(node, env)
+	case *ast.PostfixExpression:
+		return evalPostfixExpression(node, env)
 	case *ast.Identifier:
 		return evalIdentifier(node, env)
 	case *ast.VibeLiteral:
 		params := node.Parameters
 		body := node.Body
-		return &object.Vibe{Parameters: params, Env: env, Body: body}
+		return &object.Vibe{Name: node.Name, Parameters: params, Defaults: node.Defaults, Rest: node.Rest, Env: env, Body: body}
 	case *ast.CallExpression:
 		function := Eval(node.Function, env)
 		if isError(function) {
@@ -75,6 +123,8 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return args[0]
 		}
 		return applyVibe(function, args)
+	case *ast.InterpolatedString:
+		return evalInterpolatedString(node, env)
 	case *ast.StringLiteral:
 		return &object.String{Value: node.Value}
 	case *ast.ArrayLiteral:
@@ -97,10 +147,112 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	return nil
 }
 
+func evalAssignExpression(node *ast.AssignExpression, env *object.Environment) object.Object {
+	compound := node.Operator != "="
+	return evalAssignment(node.Target, env, compound, func(current object.Object) object.Object {
+		val := Eval(node.Value, env)
+		if isError(val) || !compound {
+			return val
+		}
+		// x += y stores x + y, so it gets the same semantics and errors
+		return evalInfixExpression(strings.TrimSuffix(node.Operator, "="), current, val, env.Checked())
+	})
+}
+
+func evalPostfixExpression(node *ast.PostfixExpression, env *object.Environment) object.Object {
+	var old object.Object
+	result := evalAssignment(node.Left, env, true, func(current object.Object) object.Object {
+		old = current
+		return evalInfixExpression(node.Operator[:1], current, &object.Integer{Value: 1}, env.Checked())
+	})
+	if isError(result) {
+		return result
+	}
+	return old
+}
+
+// evalAssignment stores update(current) in target and returns the stored
+// value. Target must be an identifier or an index expression; its current
+// value is only looked up, and passed to update, when needsCurrent is set.
+func evalAssignment(
+	target ast.Expression,
+	env *object.Environment,
+	needsCurrent bool,
+	update func(current object.Object) object.Object,
+) object.Object {
+	switch target := target.(type) {
+	case *ast.Identifier:
+		var current object.Object
+		if needsCurrent {
+			val, ok := env.Get(target.Value)
+			if !ok {
+				return newError("bruh moment! can't assign to undeclared identifier: %s (yeet it first)", target.Value)
+			}
+			current = val
+		}
+		val := update(current)
+		if isError(val) {
+			return val
+		}
+		if _, ok := env.Assign(target.Value, val); !ok {
+			return newError("bruh moment! can't assign to undeclared identifier: %s (yeet it first)", target.Value)
+		}
+		return val
+	case *ast.IndexExpression:
+		left := Eval(target.Left, env)
+		if isError(left) {
+			return left
+		}
+		index := Eval(target.Index, env)
+		if isError(index) {
+			return index
+		}
+		var current object.Object
+		if needsCurrent {
+			current = evalIndexExpression(left, index)
+			if isError(current) {
+				return current
+			}
+		}
+		val := update(current)
+		if isError(val) {
+			return val
+		}
+		return evalIndexAssignment(left, index, val)
+	default:
+		return newError("can't assign to %s, only to a name or an index", target.String())
+	}
+}
+
+func evalIndexAssignment(left, index, val object.Object) object.Object {
+	switch {
+	case left.Type() == object.ARRAY_OBJ && index.Type() == object.INTEGER_OBJ:
+		arrayObject := left.(*object.Array)
+		idx, ok := index.(*object.Integer)
+		if !ok || idx.Value < 0 || idx.Value >= int64(len(arrayObject.Elements)) {
+			return newError("index %s is out of bounds for an array of length %d, ratio",
+				index.Inspect(), len(arrayObject.Elements))
+		}
+		arrayObject.Elements[idx.Value] = val
+	case left.Type() == object.HASH_OBJ:
+		hashObject := left.(*object.Hash)
+		key, ok := index.(object.Hashable)
+		if !ok {
+			return newError("nah fam %s cannot be used as a hash key", index.Type())
+		}
+		hashObject.Pairs[key.HashKey()] = object.HashPair{Key: index, Value: val}
+	default:
+		return newError("index assignment deadass not supported: %s", left.Type())
+	}
+	return val
+}
+
 func evalIndexExpression(left, index object.Object) object.Object {
 	switch {
 	case left.Type() == object.ARRAY_OBJ && index.Type() == object.INTEGER_OBJ:
 		return evalArrayIndexExpression(left, index)
+	case left.Type() == object.STRING_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalStringIndexExpression(left, index)
 	case left.Type() == object.HASH_OBJ:
 		return evalHashIndexExpression(left, index)
 	default:
@@ -121,9 +273,30 @@ func evalHashIndexExpression(hash, index object.Object) object.Object {
 	return pair.Value
 }
 
+// evalStringIndexExpression returns the character at index, counting
+// characters rather than bytes, as a one-character string.
+func evalStringIndexExpression(str, index object.Object) object.Object {
+	integer, ok := index.(*object.Integer)
+	if !ok || integer.Value < 0 {
+		return NULL
+	}
+	i := int64(0)
+	for _, ch := range str.(*object.String).Value {
+		if i == integer.Value {
+			return &object.String{Value: string(ch)}
+		}
+		i++
+	}
+	return NULL
+}
+
 func evalArrayIndexExpression(array, index object.Object) object.Object {
 	arrayObject := array.(*object.Array)
-	idx := index.(*object.Integer).Value
+	integer, ok := index.(*object.Integer)
+	if !ok {
+		return NULL // a big integer is out of range of any array
+	}
+	idx := integer.Value
 	max := int64(len(arrayObject.Elements) - 1)
 	if idx < 0 || idx > max {
 		return NULL
@@ -134,7 +307,13 @@ func evalArrayIndexExpression(array, index object.Object) object.Object {
 func applyVibe(vb object.Object, args []object.Object) object.Object {
 	switch vb := vb.(type) {
 	case *object.Vibe:
-		extendedEnv := extendVibeEnv(vb, args)
+		if min, max := arity(vb); len(args) < min || (max >= 0 && len(args) > max) {
+			return wrongArgCountError(vb, len(args))
+		}
+		extendedEnv, err := extendVibeEnv(vb, args)
+		if err != nil {
+			return err
+		}
 		evaluated := Eval(vb.Body, extendedEnv)
 		return unwrapSlayvalue(evaluated)
 	case *object.Builtin:
@@ -144,13 +323,70 @@ func applyVibe(vb object.Object, args []object.Object) object.Object {
 	}
 }
 
-func extendVibeEnv(vb *object.Vibe, args []object.Object) *object.Environment {
+// arity returns the fewest and most args vb accepts; max is -1 when a
+// ...rest parameter takes any number of extra args.
+func arity(vb *object.Vibe) (min, max int) {
+	for i := range vb.Parameters {
+		if i >= len(vb.Defaults) || vb.Defaults[i] == nil {
+			min = i + 1
+		}
+	}
+	if vb.Rest != nil {
+		return min, -1
+	}
+	return min, len(vb.Parameters)
+}
+
+func wrongArgCountError(vb *object.Vibe, got int) *object.Error {
+	name := "this vibe"
+	if vb.Name != "" {
+		name = "vibe " + vb.Name
+	}
+
+	var want string
+	switch min, max := arity(vb); {
+	case max < 0:
+		want = "at least " + pluralArgs(min)
+	case min == max:
+		want = pluralArgs(max)
+	default:
+		want = fmt.Sprintf("%d to %s", min, pluralArgs(max))
+	}
+	return newError("bruh, %s wants %s but got %d", name, want, got)
+}
+
+func pluralArgs(n int) string {
+	if n == 1 {
+		return "1 arg"
+	}
+	return fmt.Sprintf("%d args", n)
+}
+
+func extendVibeEnv(vb *object.Vibe, args []object.Object) (*object.Environment, object.Object) {
 	env := object.NewEnclosedEnvironment(vb.Env)
 
 	for paramIdx, param := range vb.Parameters {
-		env.Set(param.Value, args[paramIdx])
+		if paramIdx < len(args) {
+			env.Set(param.Value, args[paramIdx])
+			continue
+		}
+		// Missing trailing args fall back to their defaults, which can see
+		// the closure and the params before them
+		val := Eval(vb.Defaults[paramIdx], env)
+		if isError(val) {
+			return nil, val
+		}
+		env.Set(param.Value, val)
+	}
+
+	if vb.Rest != nil {
+		rest := []object.Object{}
+		if len(args) > len(vb.Parameters) {
+			rest = append(rest, args[len(vb.Parameters):]...)
+		}
+		env.Set(vb.Rest.Value, &object.Array{Elements: rest})
 	}
-	return env
+	return env, nil
 }
 
 func unwrapSlayvalue(obj object.Object) object.Object {
@@ -191,7 +427,8 @@ func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) obje
 		result = Eval(statement, env)
 		if result != nil {
 			rt := result.Type()
-			if rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ {
+			if rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ ||
+				rt == object.GHOST_OBJ || rt == object.NVM_OBJ {
 				return result
 			}
 		}
@@ -229,6 +466,109 @@ func evalFrExpression(ie *ast.FrExpression, env *object.Environment) object.Obje
 	}
 }
 
+func evalGrindStatement(gs *ast.GrindStatement, env *object.Environment) object.Object {
+	for {
+		condition := Eval(gs.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+		if !isTruthy(condition) {
+			return nil
+		}
+
+		if result, done := evalLoopBody(gs.Body, env); done {
+			return result
+		}
+	}
+}
+
+func evalGrindInStatement(gs *ast.GrindInStatement, env *object.Environment) object.Object {
+	iterable := Eval(gs.Iterable, env)
+	if isError(iterable) {
+		return iterable
+	}
+
+	// Each iteration gets its own scope, so vibes created in the body
+	// capture that iteration's values
+	iterate := func(key, value object.Object) (object.Object, bool) {
+		iterEnv := object.NewEnclosedEnvironment(env)
+		if gs.Key != nil {
+			iterEnv.Set(gs.Key.Value, key)
+		}
+		iterEnv.Set(gs.Value.Value, value)
+		return evalLoopBody(gs.Body, iterEnv)
+	}
+
+	switch iterable := iterable.(type) {
+	case *object.Array:
+		for i, el := range iterable.Elements {
+			if result, done := iterate(&object.Integer{Value: int64(i)}, el); done {
+				return result
+			}
+		}
+	case *object.String:
+		i := 0
+		for _, ch := range iterable.Value {
+			if result, done := iterate(&object.Integer{Value: int64(i)}, &object.String{Value: string(ch)}); done {
+				return result
+			}
+			i++
+		}
+	case *object.Hash:
+		for _, pair := range sortedHashPairs(iterable) {
+			value := pair.Value
+			if gs.Key == nil {
+				value = pair.Key
+			}
+			if result, done := iterate(pair.Key, value); done {
+				return result
+			}
+		}
+	default:
+		return newError("can't grind over %s, it's not giving iterable", iterable.Type())
+	}
+	return nil
+}
+
+// evalLoopBody runs one iteration of a loop body and reports whether the
+// loop is done, along with what it should evaluate to if so.
+func evalLoopBody(body *ast.BlockStatement, env *object.Environment) (object.Object, bool) {
+	result := Eval(body, env)
+	if result == GHOST {
+		return nil, true
+	}
+	if result != nil {
+		rt := result.Type()
+		if rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ {
+			return result, true
+		}
+	}
+	return nil, false
+}
+
+// sortedHashPairs returns the pairs of hash ordered by key, so iterating a
+// hash always visits its pairs in the same order.
+func sortedHashPairs(hash *object.Hash) []object.HashPair {
+	pairs := make([]object.HashPair, 0, len(hash.Pairs))
+	for _, pair := range hash.Pairs {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		a, b := pairs[i].Key, pairs[j].Key
+		if a.Type() != b.Type() {
+			return a.Type() < b.Type()
+		}
+		switch a := a.(type) {
+		case *object.Integer, *object.BigInteger:
+			return compareIntegers(a, b) < 0
+		case *object.Boolean:
+			return !a.Value && b.(*object.Boolean).Value
+		}
+		return a.Inspect() < b.Inspect()
+	})
+	return pairs
+}
+
 func isTruthy(obj object.Object) bool {
 	switch obj {
 	case NULL:
@@ -242,10 +582,12 @@ func isTruthy(obj object.Object) bool {
 	}
 }
 
-func evalInfixExpression(operator string, left, right object.Object) object.Object {
+func evalInfixExpression(operator string, left, right object.Object, checked bool) object.Object {
 	switch {
 	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
-		return evalIntegerInfixExpression(operator, left, right)
+		return evalIntegerInfixExpression(operator, left, right, checked)
+	case isNumber(left) && isNumber(right):
+		return evalFloatInfixExpression(operator, left, right)
 	case operator == "==":
 		return nativeBoolToBooleanObject(left == right)
 	case operator == "!=":
@@ -261,6 +603,41 @@ func evalInfixExpression(operator string, left, right object.Object) object.Obje
 	}
 }
 
+// evalInterpolatedString renders an f-string, showing each placeholder's
+// value the way Inspect does.
+func evalInterpolatedString(node *ast.InterpolatedString, env *object.Environment) object.Object {
+	var out strings.Builder
+	for _, part := range node.Parts {
+		if text, ok := part.(*ast.StringLiteral); ok {
+			out.WriteString(text.Value)
+			continue
+		}
+		val := Eval(part, env)
+		if isError(val) {
+			return val
+		}
+		out.WriteString(val.Inspect())
+	}
+	return &object.String{Value: out.String()}
+}
+
+// evalLogicalExpression evaluates && and ||, only evaluating the right side
+// when the left side doesn't already decide the result. The result is
+// whichever operand decided it, not necessarily a boolean.
+func evalLogicalExpression(node *ast.InfixExpression, env *object.Environment) object.Object {
+	left := Eval(node.Left, env)
+	if isError(left) {
+		return left
+	}
+	if node.Operator == "&&" && !isTruthy(left) {
+		return left
+	}
+	if node.Operator == "||" && isTruthy(left) {
+		return left
+	}
+	return Eval(node.Right, env)
+}
+
 func evalStatements(stmts []ast.Statement, env *object.Environment) object.Object {
 	var result object.Object
 
@@ -281,12 +658,12 @@ func nativeBoolToBooleanObject(input bool) *object.Boolean {
 	return CAP
 }
 
-func evalPrefixExpression(operator string, right object.Object) object.Object {
+func evalPrefixExpression(operator string, right object.Object, checked bool) object.Object {
 	switch operator {
 	case "!":
 		return evalBangOperatorExpression(right)
 	case "-":
-		return evalMinusPrefixOperatorExpression(right)
+		return evalMinusPrefixOperatorExpression(right, checked)
 	default:
 		return newError("we don't do that here. unknown operator: %s%s", operator, right.Type())
 	}
@@ -305,43 +682,347 @@ func evalBangOperatorExpression(right object.Object) object.Object {
 	}
 }
 
-func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
-	if right.Type() != object.INTEGER_OBJ {
+func evalMinusPrefixOperatorExpression(right object.Object, checked bool) object.Object {
+	switch right := right.(type) {
+	case *object.Float:
+		return &object.Float{Value: -right.Value}
+	case *object.BigInteger:
+		return normalizeInteger(new(big.Int).Neg(right.Value))
+	case *object.Integer:
+		if right.Value != math.MinInt64 {
+			return &object.Integer{Value: -right.Value}
+		}
+		if checked {
+			return newError("integer overflow, it's giving too much: -(%d)", right.Value)
+		}
+		return normalizeInteger(new(big.Int).Neg(big.NewInt(right.Value)))
+	default:
 		return newError("we don't do that here. unknown operator: -%s", right.Type())
 	}
-	value := right.(*object.Integer).Value
-	return &object.Integer{Value: -value}
 }
 
-func evalIntegerInfixExpression(operator string, left, right object.Object) object.Object {
-	leftval := left.(*object.Integer).Value
-	rightval := right.(*object.Integer).Value
+// evalIntegerInfixExpression works on int64s while the operands and result
+// fit, and on big integers once they don't. When checked is set, a result
+// that doesn't fit in an int64 is an error instead.
+func evalIntegerInfixExpression(operator string, left, right object.Object, checked bool) object.Object {
+	l, leftSmall := left.(*object.Integer)
+	r, rightSmall := right.(*object.Integer)
+	if leftSmall && rightSmall {
+		if result, ok := evalInt64InfixExpression(operator, l.Value, r.Value); ok {
+			return result
+		}
+	}
+	return evalBigIntegerInfixExpression(operator, toBig(left), toBig(right), checked)
+}
 
+// evalInt64InfixExpression returns false when the result doesn't fit in an
+// int64.
+func evalInt64InfixExpression(operator string, leftval, rightval int64) (object.Object, bool) {
 	switch operator {
 	case "+":
-		return &object.Integer{Value: leftval + rightval}
+		sum := leftval + rightval
+		if (leftval >= 0) == (rightval >= 0) && (sum >= 0) != (leftval >= 0) {
+			return nil, false
+		}
+		return &object.Integer{Value: sum}, true
 	case "-":
-		return &object.Integer{Value: leftval - rightval}
+		diff := leftval - rightval
+		if (leftval >= 0) != (rightval >= 0) && (diff >= 0) != (leftval >= 0) {
+			return nil, false
+		}
+		return &object.Integer{Value: diff}, true
 	case "*":
-		return &object.Integer{Value: leftval * rightval}
-	case "/":
-		return &object.Integer{Value: leftval / rightval}
+		product, ok := mulInt64(leftval, rightval)
+		if !ok {
+			return nil, false
+		}
+		return &object.Integer{Value: product}, true
+	case "/", "%":
+		if rightval == 0 {
+			return newError("dividing by zero is not it, touch grass: %d %s 0", leftval, operator), true
+		}
+		if operator == "%" {
+			return &object.Integer{Value: leftval % rightval}, true
+		}
+		if leftval == math.MinInt64 && rightval == -1 {
+			return nil, false
+		}
+		return &object.Integer{Value: leftval / rightval}, true
+	case "**":
+		if rightval < 0 {
+			return newError("negative exponents are not it for integers: %d ** %d", leftval, rightval), true
+		}
+		power, ok := intPow(leftval, rightval)
+		if !ok {
+			return nil, false
+		}
+		return &object.Integer{Value: power}, true
+	case "&":
+		return &object.Integer{Value: leftval & rightval}, true
+	case "|":
+		return &object.Integer{Value: leftval | rightval}, true
+	case "^":
+		return &object.Integer{Value: leftval ^ rightval}, true
+	case "<<", ">>":
+		if rightval < 0 {
+			return newError("negative shift counts are not it: %d %s %d", leftval, operator, rightval), true
+		}
+		if operator == ">>" {
+			return &object.Integer{Value: leftval >> rightval}, true
+		}
+		if leftval == 0 {
+			return &object.Integer{Value: 0}, true
+		}
+		if rightval >= 63 || (leftval<<rightval)>>rightval != leftval {
+			return nil, false
+		}
+		return &object.Integer{Value: leftval << rightval}, true
 	case "<":
-		return nativeBoolToBooleanObject((leftval < rightval))
+		return nativeBoolToBooleanObject((leftval < rightval)), true
 	case ">":
-		return nativeBoolToBooleanObject((leftval > rightval))
+		return nativeBoolToBooleanObject((leftval > rightval)), true
+	case "<=":
+		return nativeBoolToBooleanObject((leftval <= rightval)), true
+	case ">=":
+		return nativeBoolToBooleanObject((leftval >= rightval)), true
 	case "==":
-		return nativeBoolToBooleanObject((leftval == rightval))
+		return nativeBoolToBooleanObject((leftval == rightval)), true
 	case "!=":
-		return nativeBoolToBooleanObject((leftval != rightval))
+		return nativeBoolToBooleanObject((leftval != rightval)), true
 
 	default:
 		return newError(
 			"we don't do that here. unknown operator: %s %s %s",
-			left.Type(),
+			object.INTEGER_OBJ,
 			operator,
-			right.Type(),
-		)
+			object.INTEGER_OBJ,
+		), true
+	}
+}
+
+// maxIntegerBits caps how big an integer can get, so 2 ** 99999999999 is an
+// error instead of eating all the memory.
+const maxIntegerBits = 1 << 22
+
+func evalBigIntegerInfixExpression(operator string, leftval, rightval *big.Int, checked bool) object.Object {
+	if bits, ok := resultBits(operator, leftval, rightval); !ok || bits > maxIntegerBits {
+		return newError("integer would be way too big, touch grass: %s %s %s", leftval, operator, rightval)
+	}
+
+	var result *big.Int
+
+	switch operator {
+	case "+":
+		result = new(big.Int).Add(leftval, rightval)
+	case "-":
+		result = new(big.Int).Sub(leftval, rightval)
+	case "*":
+		result = new(big.Int).Mul(leftval, rightval)
+	case "/", "%":
+		if rightval.Sign() == 0 {
+			return newError("dividing by zero is not it, touch grass: %s %s 0", leftval, operator)
+		}
+		if operator == "%" {
+			result = new(big.Int).Rem(leftval, rightval)
+		} else {
+			result = new(big.Int).Quo(leftval, rightval)
+		}
+	case "**":
+		if rightval.Sign() < 0 {
+			return newError("negative exponents are not it for integers: %s ** %s", leftval, rightval)
+		}
+		result = new(big.Int).Exp(leftval, rightval, nil)
+	case "&":
+		result = new(big.Int).And(leftval, rightval)
+	case "|":
+		result = new(big.Int).Or(leftval, rightval)
+	case "^":
+		result = new(big.Int).Xor(leftval, rightval)
+	case "<<", ">>":
+		if rightval.Sign() < 0 {
+			return newError("negative shift counts are not it: %s %s %s", leftval, operator, rightval)
+		}
+		if !rightval.IsUint64() || rightval.Uint64() > math.MaxUint32 {
+			return newError("shift count %s is way too big, touch grass", rightval)
+		}
+		if operator == "<<" {
+			result = new(big.Int).Lsh(leftval, uint(rightval.Uint64()))
+		} else {
+			result = new(big.Int).Rsh(leftval, uint(rightval.Uint64()))
+		}
+	case "<":
+		return nativeBoolToBooleanObject(leftval.Cmp(rightval) < 0)
+	case ">":
+		return nativeBoolToBooleanObject(leftval.Cmp(rightval) > 0)
+	case "<=":
+		return nativeBoolToBooleanObject(leftval.Cmp(rightval) <= 0)
+	case ">=":
+		return nativeBoolToBooleanObject(leftval.Cmp(rightval) >= 0)
+	case "==":
+		return nativeBoolToBooleanObject(leftval.Cmp(rightval) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(leftval.Cmp(rightval) != 0)
+	default:
+		return newError("we don't do that here. unknown operator: %s %s %s",
+			object.INTEGER_OBJ, operator, object.INTEGER_OBJ)
+	}
+
+	if checked && !result.IsInt64() {
+		return newError("integer overflow, it's giving too much: %s %s %s", leftval, operator, rightval)
+	}
+	return normalizeInteger(result)
+}
+
+// resultBits returns an upper bound on the bit length of leftval operator
+// rightval for the operators whose results can grow fast. It returns false
+// when the bound doesn't even fit in a uint64.
+func resultBits(operator string, leftval, rightval *big.Int) (uint64, bool) {
+	left := uint64(leftval.BitLen())
+	switch operator {
+	case "*":
+		return left + uint64(rightval.BitLen()), true
+	case "**":
+		// 0, 1 and -1 stay small whatever the exponent
+		if left <= 1 || rightval.Sign() <= 0 {
+			return 0, true
+		}
+		if !rightval.IsUint64() || rightval.Uint64() > math.MaxUint64/left {
+			return 0, false
+		}
+		return left * rightval.Uint64(), true
+	case "<<":
+		if left == 0 || rightval.Sign() <= 0 {
+			return 0, true
+		}
+		if !rightval.IsUint64() {
+			return 0, false
+		}
+		return left + rightval.Uint64(), left+rightval.Uint64() >= left
+	}
+	return 0, true
+}
+
+// normalizeInteger returns n as an Integer when it fits in an int64, so a
+// BigInteger never holds a small value.
+func normalizeInteger(n *big.Int) object.Object {
+	if n.IsInt64() {
+		return &object.Integer{Value: n.Int64()}
+	}
+	return &object.BigInteger{Value: n}
+}
+
+func toBig(obj object.Object) *big.Int {
+	if b, ok := obj.(*object.BigInteger); ok {
+		return b.Value
+	}
+	return big.NewInt(obj.(*object.Integer).Value)
+}
+
+// compareIntegers returns -1, 0 or +1 as a is less than, equal to or greater
+// than b.
+func compareIntegers(a, b object.Object) int {
+	if a, ok := a.(*object.Integer); ok {
+		if b, ok := b.(*object.Integer); ok {
+			switch {
+			case a.Value < b.Value:
+				return -1
+			case a.Value > b.Value:
+				return 1
+			}
+			return 0
+		}
+	}
+	return toBig(a).Cmp(toBig(b))
+}
+
+// mulInt64 returns the wrapped product of a and b and whether it fits in an
+// int64.
+func mulInt64(a, b int64) (int64, bool) {
+	product := a * b
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return product, false
+	}
+	return product, product/b == a
+}
+
+// intPow returns base ** exp for exp >= 0 by repeated squaring, and whether
+// the result fits in an int64.
+func intPow(base, exp int64) (int64, bool) {
+	result, ok := int64(1), true
+	for exp > 0 {
+		var fits bool
+		if exp&1 == 1 {
+			result, fits = mulInt64(result, base)
+			ok = ok && fits
+		}
+		exp >>= 1
+		if exp > 0 {
+			base, fits = mulInt64(base, base)
+			ok = ok && fits
+		}
+	}
+	return result, ok
+}
+
+func isNumber(obj object.Object) bool {
+	return obj.Type() == object.INTEGER_OBJ || obj.Type() == object.FLOAT_OBJ
+}
+
+// toFloat promotes an integer to a float, so mixed arithmetic happens in
+// floats.
+func toFloat(obj object.Object) *object.Float {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return &object.Float{Value: float64(obj.Value)}
+	case *object.BigInteger:
+		f, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return &object.Float{Value: f}
+	}
+	return obj.(*object.Float)
+}
+
+// evalFloatInfixExpression promotes an integer operand to a float. Errors
+// still show the operands as they were written.
+func evalFloatInfixExpression(operator string, left, right object.Object) object.Object {
+	leftval := toFloat(left).Value
+	rightval := toFloat(right).Value
+
+	switch operator {
+	case "+":
+		return &object.Float{Value: leftval + rightval}
+	case "-":
+		return &object.Float{Value: leftval - rightval}
+	case "*":
+		return &object.Float{Value: leftval * rightval}
+	case "/", "%":
+		if rightval == 0 {
+			return newError("dividing by zero is not it, touch grass: %s %s %s",
+				left.Inspect(), operator, right.Inspect())
+		}
+		if operator == "%" {
+			return &object.Float{Value: math.Mod(leftval, rightval)}
+		}
+		return &object.Float{Value: leftval / rightval}
+	case "**":
+		return &object.Float{Value: math.Pow(leftval, rightval)}
+	case "<":
+		return nativeBoolToBooleanObject(leftval < rightval)
+	case ">":
+		return nativeBoolToBooleanObject(leftval > rightval)
+	case "<=":
+		return nativeBoolToBooleanObject(leftval <= rightval)
+	case ">=":
+		return nativeBoolToBooleanObject(leftval >= rightval)
+	case "==":
+		return nativeBoolToBooleanObject(leftval == rightval)
+	case "!=":
+		return nativeBoolToBooleanObject(leftval != rightval)
+	default:
+		return newError("we don't do that here. unknown operator: %s %s %s",
+			left.Type(), operator, right.Type())
 	}
 }
 
@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"math"
 	"testing"
 
 	"github.com/Jitesh117/brainrotLang-interpreter/lexer"
@@ -45,6 +46,15 @@ func testEval(input string) object.Object {
 	return Eval(program, env)
 }
 
+// testEvalChecked is testEval with int64 overflow reported as an error.
+func testEvalChecked(input string) object.Object {
+	p := parser.New(lexer.New(input))
+	env := object.NewEnvironment()
+	env.SetChecked(true)
+
+	return Eval(p.ParseProgram(), env)
+}
+
 func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
 	result, ok := obj.(*object.Integer)
 	if !ok {
@@ -522,3 +532,756 @@ func TestHashIndexExpressions(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorPositions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"5 + based;", "1:3"},
+		{"yeet x = 1;\n  foobar;", "2:3"},
+		{"yeet f = vibe(x) {\n  x + cap\n};\nf(1);", "2:5"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+		if errObj.Pos.String() != tt.expected {
+			t.Errorf("wrong error position. expected=%q, got=%q", tt.expected, errObj.Pos)
+		}
+	}
+}
+
+func TestVibeStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"vibe add(a, b) { slay a + b; } add(10, 20);", 30},
+		{"vibe five() { 5 } five()", 5},
+		{`
+		vibe fib(n) {
+			fr (n < 2) {
+				slay n;
+			}
+			slay fib(n - 1) + fib(n - 2);
+		}
+		fib(10);
+		`, 55},
+		{`
+		vibe outer() {
+			vibe inner(x) { x * 2 }
+			inner(21)
+		}
+		outer();
+		`, 42},
+	}
+
+	for _, tt := range tests {
+		testIntegerObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestGrindStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"grind (cap) { 1 }", nil},
+		{"yeet i = 0; yeet total = 0; grind (i < 10) { yeet i = i + 1; yeet total = total + i; } total", 55},
+		{"yeet i = 0; grind (based) { yeet i = i + 1; fr (i == 5) { ghost; } } i", 5},
+		{`
+		yeet i = 0;
+		yeet odds = 0;
+		grind (i < 10) {
+			yeet i = i + 1;
+			fr (i / 2 * 2 == i) { nvm; }
+			yeet odds = odds + i;
+		}
+		odds
+		`, 25},
+		{`
+		vibe find(arr, target) {
+			yeet i = 0;
+			grind (based) {
+				fr (arr[i] == target) { slay i; }
+				yeet i = i + 1;
+			}
+		}
+		find([4, 8, 15, 16], 15);
+		`, 2},
+		{`
+		yeet i = 0;
+		yeet n = 0;
+		grind (i < 3) {
+			yeet i = i + 1;
+			yeet j = 0;
+			grind (based) {
+				yeet j = j + 1;
+				fr (j > 2) { ghost; }
+				yeet n = n + 1;
+			}
+		}
+		n
+		`, 6},
+		{"grind (1 + based) { 1 }", "L + ratio + type mismatch: INTEGER + BOOLEAN"},
+		{"grind (based) { 1 + based }", "L + ratio + type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		default:
+			if evaluated != nil {
+				t.Errorf("object is not nil. got=%T (%+v)", evaluated, evaluated)
+			}
+		}
+	}
+}
+
+func TestGrindInStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`
+		vibe indexOf(arr, target) {
+			grind (i, x in arr) {
+				fr (x == target) { slay i; }
+			}
+			slay -1;
+		}
+		indexOf([4, 8, 15, 16], 15) * 10 + indexOf([1], 2);
+		`, 19},
+		{`grind (x in [1, 2, 3, 4]) { fr (x < 3) { nvm; } slay x; }`, 3},
+		{`grind (x in [1, 2, 3]) { fr (x == 2) { ghost; } } 7`, 7},
+		{`grind (k in {"b": 1, "a": 2, "c": 3}) { slay k; }`, "a"},
+		{`grind (k, v in {"b": 1, "a": 2}) { fr (v == 1) { slay k; } }`, "b"},
+		{`grind (k in {3: "x", 10: "y", 2: "z"}) { slay k; }`, 2},
+		{`grind (i, c in "héllo") { fr ({"l": based}[c]) { slay i; } }`, 2},
+		{`grind (i, c in "héllo") { fr (i == 1) { slay c; } }`, "é"},
+		{`yeet x = 10; grind (y in [1, 2]) { yeet x = 99; } x`, 10},
+		{`grind (y in [1]) { } y`, "bruh moment! identifier not found: y"},
+		{`grind (x in 5) { }`, "can't grind over INTEGER, it's not giving iterable"},
+		{`grind (x in [1]) { x + cap }`, "L + ratio + type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			switch obj := evaluated.(type) {
+			case *object.String:
+				if obj.Value != expected {
+					t.Errorf("String has wrong value. expected=%q, got=%q", expected, obj.Value)
+				}
+			case *object.Error:
+				if obj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, obj.Message)
+				}
+			default:
+				t.Errorf("object is not String or Error. got=%T (%+v)", evaluated, evaluated)
+			}
+		}
+	}
+}
+
+func TestAssignExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"yeet x = 1; x = 5; x", 5},
+		{"yeet x = 1; x = 5", 5},
+		{"yeet x = 1; yeet y = 2; x = y = 7; x + y", 14},
+		{`
+		yeet counter = vibe() {
+			yeet n = 0;
+			vibe() { n = n + 1; n };
+		};
+		yeet c = counter();
+		c(); c();
+		c();
+		`, 3},
+		{"yeet total = 0; grind (x in [1, 2, 3]) { total = total + x; } total", 6},
+		{"yeet i = 0; grind (i < 5) { i = i + 1; } i", 5},
+		{"yeet arr = [1, 2, 3]; arr[1] = 20; arr[0] + arr[1] + arr[2]", 24},
+		{"yeet arr = [1, 2, 3]; yeet alias = arr; alias[2] = 30; arr[2]", 30},
+		{`yeet h = {"a": 1}; h["a"] = 5; h["b"] = 6; h["a"] + h["b"]`, 11},
+		{"yeet grid = [[0, 0], [0, 0]]; grid[1][0] = 9; grid[1][0]", 9},
+		{"x = 5", "bruh moment! can't assign to undeclared identifier: x (yeet it first)"},
+		{"vibe f() { y = 1; } f()", "bruh moment! can't assign to undeclared identifier: y (yeet it first)"},
+		{"yeet arr = [1]; arr[1] = 2", "index 1 is out of bounds for an array of length 1, ratio"},
+		{"yeet arr = [1]; arr[-1] = 2", "index -1 is out of bounds for an array of length 1, ratio"},
+		{`yeet h = {}; h[vibe(x) { x }] = 1`, "nah fam FUNCTION cannot be used as a hash key"},
+		{`yeet s = "abc"; s[0] = "x"`, "index assignment deadass not supported: STRING"},
+		{"yeet x = 1; x = based + 1", "L + ratio + type mismatch: BOOLEAN + INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestCompoundAssignExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"yeet x = 10; x += 5; x", 15},
+		{"yeet x = 10; x -= 5; x", 5},
+		{"yeet x = 10; x *= 5; x", 50},
+		{"yeet x = 10; x /= 5; x", 2},
+		{"yeet x = 10; x %= 4; x", 2},
+		{"yeet x = 10; x += 5", 15},
+		{"17 % 5 * 2", 4},
+		{"yeet x = 1; vibe bump() { x += 10; } bump(); bump(); x", 21},
+		{"yeet arr = [1, 2]; arr[1] *= 10; arr[1]", 20},
+		{`yeet h = {"n": 1}; h["n"] += 1; h["n"]`, 2},
+		{"yeet x = 5; x++; x", 6},
+		{"yeet x = 5; x++", 5},
+		{"yeet x = 5; x--; x--; x", 3},
+		{"yeet arr = [1]; arr[0]++; arr[0]", 2},
+		{"--5", 5},
+		{"yeet x = 5; --x", 5},
+		{"yeet x = 5; --x; x", 5},
+		{"yeet x = 5; x - --x", 0},
+		{"yeet i = 0; yeet n = 0; grind (i < 4) { i++; n += i; } n", 10},
+		{`yeet s = "a"; s += "b"; rizzLevel(s)`, 2},
+		{"x += 1", "bruh moment! can't assign to undeclared identifier: x (yeet it first)"},
+		{"yeet x = 1; x += based", "L + ratio + type mismatch: INTEGER + BOOLEAN"},
+		{`yeet s = "a"; s -= "b"`, "we don't do that here. unknown operator: STRING - STRING"},
+		{`yeet s = "a"; s++`, "L + ratio + type mismatch: STRING + INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestLogicalExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"based && based", true},
+		{"based && cap", false},
+		{"cap && based", false},
+		{"cap || based", true},
+		{"cap || cap", false},
+		{"1 < 2 && 2 < 3", true},
+		{"1 > 2 || 2 > 3", false},
+		{"!cap && !cap", true},
+		{"1 && 2", 2},
+		{"cap || 7", 7},
+		{"5 || 7", 5},
+		{"yeet x = 0; cap && (x = 1); x", 0},
+		{"yeet x = 0; based || (x = 1); x", 0},
+		{"yeet x = 0; based && (x = 1); x", 1},
+		{"cap && undefinedThing", false},
+		{"based || undefinedThing", true},
+		{"based && undefinedThing", "bruh moment! identifier not found: undefinedThing"},
+		{"(1 + cap) || based", "L + ratio + type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestExtendedIntegerOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"2 ** 10", 1024},
+		{"2 ** 3 ** 2", 512},
+		{"-2 ** 2", -4},
+		{"(-2) ** 3", -8},
+		{"7 ** 0", 1},
+		{"2 ** -1", "negative exponents are not it for integers: 2 ** -1"},
+		{"1 <= 2", true},
+		{"2 <= 2", true},
+		{"3 <= 2", false},
+		{"2 >= 3", false},
+		{"2 >= 2", true},
+		{"12 & 10", 8},
+		{"12 | 10", 14},
+		{"12 ^ 10", 6},
+		{"1 << 4", 16},
+		{"-16 >> 2", -4},
+		{"1 << -1", "negative shift counts are not it: 1 << -1"},
+		{"based & cap", "we don't do that here. unknown operator: BOOLEAN & BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestDivisionByZero(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1 / 0", "dividing by zero is not it, touch grass: 1 / 0"},
+		{"7 % 0", "dividing by zero is not it, touch grass: 7 % 0"},
+		{"yeet x = 3; x /= 0", "dividing by zero is not it, touch grass: 3 / 0"},
+		{"yeet x = 3; x %= 0", "dividing by zero is not it, touch grass: 3 % 0"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, errObj.Message)
+		}
+	}
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+	tests := []struct {
+		input    string
+		promoted string
+		expected string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808", "integer overflow, it's giving too much: 9223372036854775807 + 1"},
+		{"-9223372036854775807 - 2", "-9223372036854775809", "integer overflow, it's giving too much: -9223372036854775807 - 2"},
+		{"4611686018427387904 * 2", "9223372036854775808", "integer overflow, it's giving too much: 4611686018427387904 * 2"},
+		{"2 ** 64", "18446744073709551616", "integer overflow, it's giving too much: 2 ** 64"},
+		{"(-9223372036854775807 - 1) / -1", "9223372036854775808", "integer overflow, it's giving too much: -9223372036854775808 / -1"},
+		{"-(-9223372036854775807 - 1)", "9223372036854775808", "integer overflow, it's giving too much: -(-9223372036854775808)"},
+		{"yeet x = 9223372036854775807; x++; x", "9223372036854775808", "integer overflow, it's giving too much: 9223372036854775807 + 1"},
+		{"1 << 63", "9223372036854775808", "integer overflow, it's giving too much: 1 << 63"},
+		{"vibe inc(n) { n + 1 }; inc(9223372036854775807)", "9223372036854775808", "integer overflow, it's giving too much: 9223372036854775807 + 1"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.promoted {
+			t.Errorf("wrong result for %q. expected=%s, got=%s", tt.input, tt.promoted, evaluated.Inspect())
+		}
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvalChecked(tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%q: object is not Error. got=%T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, errObj.Message)
+		}
+	}
+
+	fits := map[string]int64{
+		"9223372036854775806 + 1":        math.MaxInt64,
+		"-9223372036854775807 - 1":       math.MinInt64,
+		"-3037000499 * 3037000499":       -9223372030926249001,
+		"(-2) ** 63":                     math.MinInt64,
+		"3 ** 39":                        4052555153018976267,
+		"(-9223372036854775807 - 1) / 1": math.MinInt64,
+	}
+	for input, expected := range fits {
+		testIntegerObject(t, testEvalChecked(input), expected)
+	}
+}
+
+func TestVibeArity(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    string
+		expectedPos string
+	}{
+		{"vibe add(a, b) { a + b }\nadd(1);", "bruh, vibe add wants 2 args but got 1", "2:4"},
+		{"vibe add(a, b) { a + b }\nadd(1, 2, 3);", "bruh, vibe add wants 2 args but got 3", "2:4"},
+		{"yeet id = vibe(x) { x };  id();", "bruh, vibe id wants 1 arg but got 0", "1:29"},
+		{"vibe(x, y) { x }(1)", "bruh, this vibe wants 2 args but got 1", "1:17"},
+		{"yeet f = vibe() { 1 }; yeet g = f; g(2)", "bruh, vibe f wants 0 args but got 1", "1:37"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, errObj.Message)
+		}
+		if errObj.Pos.String() != tt.expectedPos {
+			t.Errorf("wrong error position. expected=%q, got=%q", tt.expectedPos, errObj.Pos)
+		}
+	}
+}
+
+func TestDefaultAndRestParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"yeet add = vibe(a, b = 10) { a + b }; add(1)", 11},
+		{"yeet add = vibe(a, b = 10) { a + b }; add(1, 2)", 3},
+		{"yeet f = vibe(a, b = a * 2) { b }; f(4)", 8},
+		{"yeet n = 7; yeet f = vibe(a = n) { a }; yeet n = 9; f()", 9},
+		{"vibe sum(...xs) { yeet n = 0; grind (x in xs) { n += x } n } sum()", 0},
+		{"vibe sum(...xs) { yeet n = 0; grind (x in xs) { n += x } n } sum(1, 2, 3)", 6},
+		{"vibe tail(a, ...xs) { xs[1] } tail(1, 2, 3)", 3},
+		{"vibe f(a, b = 2, ...xs) { fr (first(xs)) { 100 } sus { a + b } } f(1)", 3},
+		{"vibe f(a, b = 2, ...xs) { a + b + last(xs) } f(1, 5, 0, 4)", 10},
+		{"yeet f = vibe(a = 1) { a }; yeet x = 0; f(); f(); x", 0},
+		{"yeet f = vibe(a, b = 2) { a }; f()", "bruh, vibe f wants 1 to 2 args but got 0"},
+		{"yeet f = vibe(a, b = 2) { a }; f(1, 2, 3)", "bruh, vibe f wants 1 to 2 args but got 3"},
+		{"yeet f = vibe(a, ...xs) { a }; f()", "bruh, vibe f wants at least 1 arg but got 0"},
+		{"yeet f = vibe(a = nope) { a }; f()", "bruh moment! identifier not found: nope"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestRestParameterIsFreshArray(t *testing.T) {
+	evaluated := testEval("vibe f(...xs) { xs } f(1, 2)")
+	arr, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+	}
+	if len(arr.Elements) != 2 {
+		t.Fatalf("wrong number of elements. want 2, got=%d", len(arr.Elements))
+	}
+	testIntegerObject(t, arr.Elements[0], 1)
+	testIntegerObject(t, arr.Elements[1], 2)
+}
+
+func TestFloatExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"3.14", 3.14},
+		{"-2.5", -2.5},
+		{"1.5 + 2.25", 3.75},
+		{"1 + 0.5", 1.5},
+		{"0.5 * 4", 2.0},
+		{"7 / 2.0", 3.5},
+		{"7.5 % 2", 1.5},
+		{"2.0 ** -1", 0.5},
+		{"4 ** 0.5", 2.0},
+		{"yeet x = 1.5; x += 1; x", 2.5},
+		{"yeet x = 1.5; x++; x", 2.5},
+		{"1 == 1.0", true},
+		{"1.5 != 1.5", false},
+		{"1 < 1.5", true},
+		{"2.5 >= 3", false},
+		{"1.0 / 0", "dividing by zero is not it, touch grass: 1.0 / 0"},
+		{"1.5 % 0.0", "dividing by zero is not it, touch grass: 1.5 % 0.0"},
+		{"1.5 & 1", "we don't do that here. unknown operator: FLOAT & INTEGER"},
+		{"1 << 1.5", "we don't do that here. unknown operator: INTEGER << FLOAT"},
+		{"1.5 + based", "L + ratio + type mismatch: FLOAT + BOOLEAN"},
+		{"float(3)", 3.0},
+		{"float(\" 2.5 \")", 2.5},
+		{"float(\"nope\")", "can't turn \"nope\" into a FLOAT, no cap"},
+		{"int(3.99)", 3},
+		{"int(-3.99)", -3},
+		{"int(\"42\")", 42},
+		{"int(1.5 / 0.0 * 0)", "dividing by zero is not it, touch grass: 1.5 / 0.0"},
+		{"int(1e308 * 10.0)", "can't turn +Inf into an INTEGER, no cap"},
+		{"int(based)", "argument to `int` not supported, got BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case float64:
+			testFloatObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestFloatInspect(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"3.0", "3.0"},
+		{"1 / 4.0", "0.25"},
+		{"1e21", "1e+21"},
+		{"-0.5", "-0.5"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong Inspect for %q. expected=%q, got=%q", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func testFloatObject(t *testing.T, obj object.Object, expected float64) bool {
+	result, ok := obj.(*object.Float)
+	if !ok {
+		t.Errorf("object is not Float. got=%T (%+v)", obj, obj)
+		return false
+	}
+	if result.Value != expected {
+		t.Errorf("object has wrong value. got=%g, want=%g", result.Value, expected)
+		return false
+	}
+	return true
+}
+
+func TestBigIntegers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"99999999999999999999", "99999999999999999999"},
+		{"0xFFFF_FFFF_FFFF_FFFF_FF", "4722366482869645213695"},
+		{"1_000_000_000_000_000_000_000", "1000000000000000000000"},
+		{"-99999999999999999999", "-99999999999999999999"},
+		{"2 ** 100", "1267650600228229401496703205376"},
+		{"vibe fact(n) { fr (n < 2) { slay 1; } slay n * fact(n - 1); } fact(25)", "15511210043330985984000000"},
+		{"(2 ** 100) / (2 ** 98)", "4"},
+		{"(2 ** 64) - (2 ** 64) + 1", "1"},
+		{"(2 ** 70) % 1000", "424"},
+		{"-(2 ** 70) % 1000", "-424"},
+		{"(2 ** 64) > 5", "true"},
+		{"(2 ** 64) == (2 ** 64)", "true"},
+		{"(2 ** 64) != 2 ** 64 + 1", "true"},
+		{"(2 ** 65) >> 64", "2"},
+		{"(2 ** 64) | 1", "18446744073709551617"},
+		{"(2 ** 64) & 1", "0"},
+		{"-(2 ** 63)", "-9223372036854775808"},
+		{"0.5 * 2 ** 64", "9.223372036854776e+18"},
+		{"int(1e19)", "10000000000000000000"},
+		{"int(\"123456789012345678901234567890\")", "123456789012345678901234567890"},
+		{"[1, 2][2 ** 64]", "null"},
+		{"{2 ** 64: \"big\"}[2 ** 64]", "big"},
+		{"yeet h = {1: \"small\", 2 ** 64: \"big\", -(2 ** 64): \"neg\"}; h[1] + h[2 ** 64] + h[-(2 ** 64)] + h[(2 ** 64) / (2 ** 64)]", "smallbignegsmall"},
+		{"(2 ** 64) / 0", "ERROR: 1:11: dividing by zero is not it, touch grass: 18446744073709551616 / 0"},
+		{"(2 ** 64) ** -1", "ERROR: 1:11: negative exponents are not it for integers: 18446744073709551616 ** -1"},
+		{"2 ** 99999999999", "ERROR: 1:3: integer would be way too big, touch grass: 2 ** 99999999999"},
+		{"(3 ** 3900000) > 0", "ERROR: 1:4: integer would be way too big, touch grass: 3 ** 3900000"},
+		{"1 << 4000000000", "ERROR: 1:3: integer would be way too big, touch grass: 1 << 4000000000"},
+		{"(2 ** 2000000) > 0", "true"},
+		{"(-1) ** 99999999999", "-1"},
+		{"0 << 4000000000", "0"},
+		{"yeet xs = [1]; xs[2 ** 64] = 5", "ERROR: 1:28: index 18446744073709551616 is out of bounds for an array of length 1, ratio"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if evaluated.Inspect() != tt.expected {
+			t.Errorf("wrong result for %q. expected=%s, got=%s", tt.input, tt.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func TestBigIntegersDemote(t *testing.T) {
+	evaluated := testEval("(2 ** 64) - (2 ** 64) + 7")
+	testIntegerObject(t, evaluated, 7)
+
+	evaluated = testEval("(2 ** 64) / 2 ** 60")
+	testIntegerObject(t, evaluated, 16)
+}
+
+func TestStringEscapesEvaluate(t *testing.T) {
+	evaluated := testEval(`"line one\n\t\"two\" \u{1F480}"`)
+	str, ok := evaluated.(*object.String)
+	if !ok {
+		t.Fatalf("object is not String. got=%T (%+v)", evaluated, evaluated)
+	}
+	if str.Value != "line one\n\t\"two\" \U0001F480" {
+		t.Errorf("String has wrong value. got=%q", str.Value)
+	}
+}
+
+func TestInterpolatedStrings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`yeet name = "bestie"; f"hi {name}!"`, "hi bestie!"},
+		{`f"{1 + 2} is {based} and {1.5}"`, "3 is true and 1.5"},
+		{`f"list {[1, "a"]}"`, "list [1, a]"},
+		{`yeet x = 2; f"{f"{x * x}"}!"`, "4!"},
+		{`f"\{braces\}"`, "{braces}"},
+		{`yeet n = 0; vibe bump() { n++ } f"{bump()}{bump()}{n}"`, "012"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("object is not String. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+		if str.Value != tt.expected {
+			t.Errorf("String has wrong value. expected=%q, got=%q", tt.expected, str.Value)
+		}
+	}
+
+	evaluated := testEval(`f"a {nope} b"`)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+	}
+	if errObj.Message != "bruh moment! identifier not found: nope" {
+		t.Errorf("wrong error message. got=%q", errObj.Message)
+	}
+}
+
+func TestRawStrings(t *testing.T) {
+	evaluated := testEval("yeet s = `line \\n one\nline \"two\"`; s + \"!\"")
+	str, ok := evaluated.(*object.String)
+	if !ok {
+		t.Fatalf("object is not String. got=%T (%+v)", evaluated, evaluated)
+	}
+	if str.Value != "line \\n one\nline \"two\"!" {
+		t.Errorf("String has wrong value. got=%q", str.Value)
+	}
+}
+
+func TestUnicodeStrings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`rizzLevel("💀💀💀")`, 3},
+		{`rizzLevel("café")`, 4},
+		{`"café"[3]`, "é"},
+		{`"a💀b"[1]`, "💀"},
+		{`"a💀b"[2]`, "b"},
+		{`"abc"[3]`, nil},
+		{`"abc"[-1]`, nil},
+		{`yeet 💀 = 5; yeet café = 💀 * 2; café`, 10},
+		{`yeet n = 0; grind (i, c in "💀ab") { n = i } n`, 2},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			str, ok := evaluated.(*object.String)
+			if !ok {
+				t.Errorf("object is not String. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if str.Value != expected {
+				t.Errorf("String has wrong value. expected=%q, got=%q", expected, str.Value)
+			}
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
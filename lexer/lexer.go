@@ -1,29 +1,116 @@
 package lexer
 
-import "github.com/Jitesh117/brainrotLang-interpreter/token"
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Jitesh117/brainrotLang-interpreter/diagnostic"
+	"github.com/Jitesh117/brainrotLang-interpreter/token"
+)
+
+// Mode is a set of flags that change what the lexer emits.
+type Mode uint
+
+const (
+	ScanComments Mode = 1 << iota // emit COMMENT tokens instead of skipping comments
+)
 
 type Lexer struct {
-	input        string // the input string being analyzed
-	position     int    // current position in input (points to current char)
-	readPosition int    // current reading position in input (after current char)
-	ch           byte   // current char under examination
+	reader       io.RuneReader // where the input comes from
+	filename     string        // name of the source file, if any
+	position     int           // current position in input (points to current char)
+	readPosition int           // current reading position in input (after current char)
+	ch           rune          // current char under examination
+	next         rune          // char after ch, already taken from reader
+	nextWidth    int           // width of next in bytes, 0 at the end of the input
+	err          error         // first error from reader other than io.EOF
+	line         int           // line of the current char, starting at 1
+	column       int           // column of the current char, starting at 1
+	mode         Mode          // optional behaviour, see SetMode
+
+	literal   strings.Builder // chars read since startLiteral
+	recording bool            // whether readChar adds to literal
+
+	braceDepth   int   // number of { not yet closed
+	placeholders []int // braceDepth at each open f-string placeholder
+
+	diagnostics []diagnostic.Diagnostic // problems found in the input so far
 }
 
 // New initializes a new Lexer isntance with the provided input string.
 // It reads the first character to prime the lexer for tokenization.
 func New(input string) *Lexer {
-	l := &Lexer{input: input}
+	return NewFile("", input)
+}
+
+// NewFile initializes a new Lexer like New, but stamps every token position
+// with the given filename so errors can point back at the source file.
+func NewFile(filename, input string) *Lexer {
+	return NewFileReader(filename, strings.NewReader(input))
+}
+
+// NewReader initializes a new Lexer that reads its input from r as it goes,
+// so the whole program never has to be in memory at once.
+func NewReader(r io.Reader) *Lexer {
+	return NewFileReader("", r)
+}
+
+// NewFileReader initializes a new Lexer like NewReader, but stamps every token
+// position with the given filename. Readers that can't read a rune at a time
+// are buffered.
+func NewFileReader(filename string, r io.Reader) *Lexer {
+	rr, ok := r.(io.RuneReader)
+	if !ok {
+		rr = bufio.NewReader(r)
+	}
+	l := &Lexer{reader: rr, filename: filename, line: 1}
+	l.readNext()
 	l.readChar() // Initialise the lexer by reading the first characcter
 	return l
 }
 
+// SetMode changes what the lexer emits. It should be called before the first
+// call to NextToken.
+func (l *Lexer) SetMode(mode Mode) {
+	l.mode = mode
+}
+
+// Diagnostics returns the problems the lexer has found in the input so far,
+// such as a block comment that is never closed.
+func (l *Lexer) Diagnostics() []diagnostic.Diagnostic {
+	return l.diagnostics
+}
+
+// Err returns the first error the lexer got reading its input, other than
+// io.EOF. The input is treated as ending where the error happened.
+func (l *Lexer) Err() error {
+	return l.err
+}
+
 // NextToken examines the current characters and returns the next token.
 // It handles different types of tokens including operators, delimiters, identifers, and literals
-
 func (l *Lexer) NextToken() token.Token {
+	tok := l.readToken()
+	if tok.Type == token.EOF {
+		tok.End = tok.Pos
+	} else {
+		tok.End = l.currentPosition()
+	}
+	return tok
+}
+
+// readToken reads the next token, leaving the lexer on the character just
+// past it.
+func (l *Lexer) readToken() token.Token {
 	var tok token.Token
 
 	l.skipWhitespace() // Skip any whitespace to find the next meaningful character
+	pos := l.currentPosition()
 
 	switch l.ch {
 	case '=':
@@ -38,9 +125,21 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.ASSIGN, l.ch)
 		}
 	case '+':
-		tok = newToken(token.PLUS, l.ch)
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.PLUS_ASSIGN)
+		} else if l.peekChar() == '+' {
+			tok = l.newTwoCharToken(token.INCREMENT)
+		} else {
+			tok = newToken(token.PLUS, l.ch)
+		}
 	case '-':
-		tok = newToken(token.MINUS, l.ch)
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.MINUS_ASSIGN)
+		} else if l.peekChar() == '-' {
+			tok = l.newTwoCharToken(token.DECREMENT)
+		} else {
+			tok = newToken(token.MINUS, l.ch)
+		}
 	case '!':
 		// Handle '!=' for inequality comparison
 		if l.peekChar() == '=' {
@@ -53,31 +152,107 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.BANG, l.ch)
 		}
 	case '/':
-		tok = newToken(token.SLASH, l.ch)
+		// Comments only get this far in ScanComments mode, otherwise
+		// skipWhitespace has already skipped them
+		if l.peekChar() == '/' {
+			tok.Type = token.COMMENT
+			tok.Literal = l.readLineComment()
+			tok.Pos = pos
+			return tok
+		} else if l.peekChar() == '*' {
+			tok.Type = token.COMMENT
+			tok.Literal = l.readBlockComment()
+			tok.Pos = pos
+			return tok
+		}
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.SLASH_ASSIGN)
+		} else {
+			tok = newToken(token.SLASH, l.ch)
+		}
 	case '*':
-		tok = newToken(token.ASTERISK, l.ch)
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.ASTERISK_ASSIGN)
+		} else if l.peekChar() == '*' {
+			tok = l.newTwoCharToken(token.POWER)
+		} else {
+			tok = newToken(token.ASTERISK, l.ch)
+		}
+	case '%':
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.PERCENT_ASSIGN)
+		} else {
+			tok = newToken(token.PERCENT, l.ch)
+		}
 	case '<':
-		tok = newToken(token.LT, l.ch)
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.LT_EQ)
+		} else if l.peekChar() == '<' {
+			tok = l.newTwoCharToken(token.SHIFT_LEFT)
+		} else {
+			tok = newToken(token.LT, l.ch)
+		}
 	case '>':
-		tok = newToken(token.GT, l.ch)
+		if l.peekChar() == '=' {
+			tok = l.newTwoCharToken(token.GT_EQ)
+		} else if l.peekChar() == '>' {
+			tok = l.newTwoCharToken(token.SHIFT_RIGHT)
+		} else {
+			tok = newToken(token.GT, l.ch)
+		}
+	case '&':
+		if l.peekChar() == '&' {
+			tok = l.newTwoCharToken(token.AND)
+		} else {
+			tok = newToken(token.BIT_AND, l.ch)
+		}
+	case '|':
+		if l.peekChar() == '|' {
+			tok = l.newTwoCharToken(token.OR)
+		} else {
+			tok = newToken(token.BIT_OR, l.ch)
+		}
+	case '^':
+		tok = newToken(token.BIT_XOR, l.ch)
 	case ';':
 		tok = newToken(token.SEMICOLON, l.ch)
 	case ':':
 		tok = newToken(token.COLON, l.ch)
 	case ',':
 		tok = newToken(token.COMMA, l.ch)
+	case '.':
+		if l.peekChar() == '.' {
+			l.readChar()
+			if l.peekChar() == '.' {
+				l.readChar()
+				tok = token.Token{Type: token.ELLIPSIS, Literal: "..."}
+			} else {
+				tok = token.Token{Type: token.ILLEGAL, Literal: ".."}
+			}
+		} else {
+			tok = newToken(token.ILLEGAL, l.ch)
+		}
 	case '{':
+		l.braceDepth++
 		tok = newToken(token.LBRACE, l.ch)
 	case '}':
-		tok = newToken(token.RBRACE, l.ch)
+		if n := len(l.placeholders); n > 0 && l.placeholders[n-1] == l.braceDepth {
+			// Closes an f-string placeholder, so the string carries on
+			l.placeholders = l.placeholders[:n-1]
+			tok = l.readFStringPart(pos, false)
+		} else {
+			l.braceDepth--
+			tok = newToken(token.RBRACE, l.ch)
+		}
 	case '(':
 		tok = newToken(token.LPAREN, l.ch)
 	case ')':
 		tok = newToken(token.RPAREN, l.ch)
 	case '"':
 		// Handle string literals
-		tok.Type = token.STRING
-		tok.Literal = l.readString()
+		tok.Type, tok.Literal = l.readString(pos)
+	case '`':
+		tok.Type, tok.Literal = l.readRawString(pos)
 	case '[':
 		tok = newToken(token.LBRACKET, l.ch)
 	case ']':
@@ -88,13 +263,17 @@ func (l *Lexer) NextToken() token.Token {
 		tok.Type = token.EOF
 	default:
 		// Handle identifiers and numeric literals
-		if isLetter(l.ch) {
+		if l.ch == 'f' && l.peekChar() == '"' {
+			l.readChar()
+			tok = l.readFStringPart(pos, true)
+		} else if isLetter(l.ch) {
 			tok.Literal = l.readIdentifier()
 			tok.Type = token.LookupIdent(tok.Literal)
+			tok.Pos = pos
 			return tok
 		} else if isDigit(l.ch) {
-			tok.Type = token.INT
-			tok.Literal = l.readNumber()
+			tok.Type, tok.Literal = l.readNumber(pos)
+			tok.Pos = pos
 			return tok
 		} else {
 			// Handle unknown or illegal characters
@@ -103,78 +282,446 @@ func (l *Lexer) NextToken() token.Token {
 	}
 
 	l.readChar() // Move to the next character for further analysis
+	tok.Pos = pos
 	return tok
 }
 
 // skipWhitespace advances the lexer's position past an ywhitespace characters.
+// Comments count as whitespace unless the ScanComments mode is set.
 func (l *Lexer) skipWhitespace() {
-	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+	for {
+		switch {
+		case l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r':
+			l.readChar()
+		case l.ch == '/' && l.peekChar() == '/' && l.mode&ScanComments == 0:
+			l.readLineComment()
+		case l.ch == '/' && l.peekChar() == '*' && l.mode&ScanComments == 0:
+			l.readBlockComment()
+		default:
+			return
+		}
+	}
+}
+
+// readLineComment reads a `//` comment up to, but not including, the end of the line.
+func (l *Lexer) readLineComment() string {
+	l.startLiteral()
+	for l.ch != '\n' && l.ch != 0 {
+		l.readChar()
+	}
+	return l.endLiteral()
+}
+
+// readBlockComment reads a `/* ... */` comment, including the delimiters.
+// A comment that is never closed runs to the end of the input and is reported.
+func (l *Lexer) readBlockComment() string {
+	start := l.currentPosition()
+	l.startLiteral()
+	l.readChar() // skip the '/'
+	l.readChar() // skip the '*'
+	for !(l.ch == '*' && l.peekChar() == '/') {
+		if l.ch == 0 {
+			l.addError(start, diagnostic.UnterminatedComment, "block comment is never closed, missing */")
+			return l.endLiteral()
+		}
 		l.readChar()
 	}
+	l.readChar() // skip the '*'
+	l.readChar() // skip the '/'
+	return l.endLiteral()
+}
+
+// addError records a problem with the input starting at pos and running up
+// to the current character.
+func (l *Lexer) addError(pos token.Position, code diagnostic.Code, msg string) {
+	l.diagnostics = append(l.diagnostics, diagnostic.Diagnostic{
+		Severity: diagnostic.Error,
+		Code:     code,
+		Span:     diagnostic.Span{Start: pos, End: l.currentPosition()},
+		Message:  msg,
+	})
 }
 
 // readChar reads the next character from the input and advances the lexer's positions.
 func (l *Lexer) readChar() {
-	if l.readPosition >= len(l.input) {
+	if l.recording && l.ch != 0 {
+		l.literal.WriteRune(l.ch)
+	}
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	}
+	width := 1
+	if l.nextWidth == 0 {
 		l.ch = 0 // End of input; set current character to NUL (0)
 	} else {
-		l.ch = l.input[l.readPosition] // Read the next character from input
+		l.ch, width = l.next, l.nextWidth
+		l.readNext()
 	}
 	l.position = l.readPosition // Update current position
-	l.readPosition += 1         // Advance reading position
+	l.readPosition += width     // Advance reading position
+	l.column++
 }
 
-// peekChar looks ahead at the next character without advancing the lexer's position.
-func (l *Lexer) peekChar() byte {
-	if l.readPosition >= len(l.input) {
-		return 0 // End of input; return NUL (0)
-	} else {
-		return l.input[l.readPosition] // Return the next character
+// readNext decodes the character after the current one from the reader, which
+// may take several bytes. Once the reader fails or runs out it is not read again.
+func (l *Lexer) readNext() {
+	ch, width, err := l.reader.ReadRune()
+	if err != nil {
+		if err != io.EOF {
+			l.err = err
+		}
+		l.next, l.nextWidth = 0, 0
+		return
 	}
+	l.next, l.nextWidth = ch, width
+}
+
+// startLiteral starts collecting the characters the lexer reads, beginning
+// with the current one.
+func (l *Lexer) startLiteral() {
+	l.literal.Reset()
+	l.recording = true
+}
+
+// endLiteral stops collecting and returns the characters read since
+// startLiteral, not including the current one.
+func (l *Lexer) endLiteral() string {
+	l.recording = false
+	return l.literal.String()
+}
+
+// currentPosition returns the source position of the current character.
+func (l *Lexer) currentPosition() token.Position {
+	return token.Position{
+		Filename: l.filename,
+		Offset:   l.position,
+		Line:     l.line,
+		Column:   l.column,
+	}
+}
+
+// peekChar looks ahead at the next character without advancing the lexer's position.
+func (l *Lexer) peekChar() rune {
+	return l.next // NUL (0) at the end of input
 }
 
 // readIdentifier reads an identifier or keyword from the input.
-// Identifiers start with a letter or underscore and may contain additional letters, digits, or underscores.
+// Identifiers start with a letter, underscore or emoji and may contain more of those.
 func (l *Lexer) readIdentifier() string {
-	position := l.position
-	for isLetter(l.ch) {
+	l.startLiteral()
+	for isIdentifierPart(l.ch) {
 		l.readChar()
 	}
-	return l.input[position:l.position]
+	return l.endLiteral()
 }
 
-// readNumber reads a numeric literal (integer) from the input.
-func (l *Lexer) readNumber() string {
-	position := l.position
-	for isDigit(l.ch) {
+// readNumber reads a numeric literal from the input: an integer, or a float
+// with a fraction and/or an exponent such as 3.14 or 1e-3. Integers can also
+// be written in hex, binary or octal with a 0x, 0b or 0o prefix, and any
+// digits can be grouped with _ as in 1_000_000. A literal that can't be read
+// is reported and returned as ILLEGAL.
+func (l *Lexer) readNumber(pos token.Position) (token.TokenType, string) {
+	if l.ch == '0' && strings.ContainsRune("xXbBoO", l.peekChar()) {
+		return l.readPrefixedNumber(pos)
+	}
+	l.startLiteral()
+	tokenType := token.TokenType(token.INT)
+	l.readDigits()
+	if l.ch == '.' && isDigit(l.peekChar()) {
+		tokenType = token.FLOAT
 		l.readChar()
+		l.readDigits()
 	}
-	return l.input[position:l.position]
+	if l.ch == 'e' || l.ch == 'E' {
+		tokenType = token.FLOAT
+		l.readChar()
+		if l.ch == '+' || l.ch == '-' {
+			l.readChar()
+		}
+		if !isDigit(l.ch) {
+			literal := l.endLiteral()
+			l.addError(pos, diagnostic.MalformedNumber,
+				fmt.Sprintf("malformed number %s, the exponent has no digits", literal))
+			return token.ILLEGAL, literal
+		}
+		l.readDigits()
+	}
+	literal := l.endLiteral()
+	if !separatorsOK(literal, isDigit) {
+		l.addError(pos, diagnostic.MalformedNumber,
+			fmt.Sprintf("malformed number %s, _ can only go between digits", literal))
+		return token.ILLEGAL, literal
+	}
+	return tokenType, literal
 }
 
-// readString reads a string literal from the input, including the quotes.
-func (l *Lexer) readString() string {
-	position := l.position + 1 // Skip the opening quote
-	for {
+// readDigits reads a run of decimal digits and _ separators.
+func (l *Lexer) readDigits() {
+	for isDigit(l.ch) || l.ch == '_' {
+		l.readChar()
+	}
+}
+
+// readPrefixedNumber reads an integer written with a 0x, 0b or 0o prefix.
+// Every letter and digit after the prefix is taken as part of the literal, so
+// 0xZZ is reported as one malformed number rather than 0x followed by ZZ.
+func (l *Lexer) readPrefixedNumber(pos token.Position) (token.TokenType, string) {
+	l.startLiteral()
+	l.readChar()
+	prefix := l.ch
+	l.readChar()
+	for isDigit(l.ch) || isASCIILetter(l.ch) || l.ch == '_' {
 		l.readChar()
-		if l.ch == '"' || l.ch == 0 { // End of string or input
+	}
+	literal := l.endLiteral()
+
+	valid, base := isHexDigit, "hex"
+	switch prefix {
+	case 'b', 'B':
+		valid, base = isBinaryDigit, "binary"
+	case 'o', 'O':
+		valid, base = isOctalDigit, "octal"
+	}
+
+	msg := ""
+	digits := literal[2:]
+	if digits == "" {
+		msg = fmt.Sprintf("malformed number %s, it needs %s digits after the prefix", literal, base)
+	}
+	for _, ch := range digits {
+		if ch != '_' && !valid(ch) {
+			msg = fmt.Sprintf("malformed number %s, %c is not a valid %s digit", literal, ch, base)
 			break
 		}
 	}
-	return l.input[position:l.position] // Return the string contents, excluding quotes
+	if msg == "" && !separatorsOK(digits, valid) {
+		msg = fmt.Sprintf("malformed number %s, _ can only go between digits", literal)
+	}
+	if msg != "" {
+		l.addError(pos, diagnostic.MalformedNumber, msg)
+		return token.ILLEGAL, literal
+	}
+	return token.INT, literal
+}
+
+// separatorsOK reports whether every _ in literal sits between two digits,
+// so 1_000 is fine but 1__000, 1_ and 1_.5 are not.
+func separatorsOK(literal string, isDigit func(rune) bool) bool {
+	chars := []rune(literal)
+	for i, ch := range chars {
+		if ch != '_' {
+			continue
+		}
+		if i == 0 || i == len(chars)-1 || !isDigit(chars[i-1]) || !isDigit(chars[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// readString reads a string literal from the input and returns its contents
+// with the escape sequences decoded. A string with a bad escape or no closing
+// quote is reported and returned as ILLEGAL.
+func (l *Lexer) readString(pos token.Position) (token.TokenType, string) {
+	var out strings.Builder
+	tokenType := token.TokenType(token.STRING)
+	for {
+		l.readChar()
+		switch l.ch {
+		case '"':
+			return tokenType, out.String()
+		case 0:
+			l.addError(pos, diagnostic.UnterminatedString, `string is never closed, missing "`)
+			return token.ILLEGAL, out.String()
+		case '\\':
+			if !l.readEscape(&out, false) {
+				tokenType = token.ILLEGAL
+			}
+		default:
+			out.WriteRune(l.ch)
+		}
+	}
 }
 
-// isLetter checks if a character is a letter or underscore, used to identify the start of identifiers.
-func isLetter(ch byte) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+// readRawString reads a backtick string literal, which can span lines and
+// has no escape sequences. Carriage returns are dropped, so a file saved
+// with \r\n line endings gives the same string.
+func (l *Lexer) readRawString(pos token.Position) (token.TokenType, string) {
+	var out strings.Builder
+	for {
+		l.readChar()
+		switch l.ch {
+		case '`':
+			return token.STRING, out.String()
+		case 0:
+			l.addError(pos, diagnostic.UnterminatedString, "raw string is never closed, missing `")
+			return token.ILLEGAL, out.String()
+		case '\r':
+		default:
+			out.WriteRune(l.ch)
+		}
+	}
+}
+
+// readFStringPart reads the text of an f-string up to the { opening its next
+// placeholder or up to its closing quote. The current character is the
+// opening quote when head is set, or else the } closing a placeholder.
+func (l *Lexer) readFStringPart(pos token.Position, head bool) token.Token {
+	var out strings.Builder
+	for {
+		l.readChar()
+		switch l.ch {
+		case '{':
+			l.placeholders = append(l.placeholders, l.braceDepth)
+			if head {
+				return token.Token{Type: token.FSTRING_HEAD, Literal: out.String()}
+			}
+			return token.Token{Type: token.FSTRING_MIDDLE, Literal: out.String()}
+		case '"':
+			if head {
+				return token.Token{Type: token.STRING, Literal: out.String()}
+			}
+			return token.Token{Type: token.FSTRING_TAIL, Literal: out.String()}
+		case 0:
+			l.addError(pos, diagnostic.UnterminatedString, `f-string is never closed, missing "`)
+			return token.Token{Type: token.ILLEGAL, Literal: out.String()}
+		case '\\':
+			// A bad escape is reported but keeps the token, so the
+			// placeholders around it still line up
+			l.readEscape(&out, true)
+		default:
+			out.WriteRune(l.ch)
+		}
+	}
+}
+
+// readEscape decodes the escape sequence whose backslash is the current
+// character into out. An invalid one is reported and false is returned.
+// F-strings can also escape the braces around placeholders.
+func (l *Lexer) readEscape(out *strings.Builder, fstring bool) bool {
+	pos := l.currentPosition()
+	if fstring && (l.peekChar() == '{' || l.peekChar() == '}') {
+		l.readChar()
+		out.WriteRune(l.ch)
+		return true
+	}
+	switch l.peekChar() {
+	case 'n':
+		out.WriteByte('\n')
+	case 't':
+		out.WriteByte('\t')
+	case 'r':
+		out.WriteByte('\r')
+	case '0':
+		out.WriteByte(0)
+	case '"':
+		out.WriteByte('"')
+	case '\\':
+		out.WriteByte('\\')
+	case 'u':
+		l.readChar()
+		return l.readUnicodeEscape(pos, out)
+	case 0:
+		// Leave the missing quote for readString to report
+		return true
+	default:
+		l.readChar()
+		l.addError(pos, diagnostic.InvalidEscape, fmt.Sprintf("unknown escape sequence \\%c in string", l.ch))
+		return false
+	}
+	l.readChar()
+	return true
+}
+
+// readUnicodeEscape decodes the {XXXX} part of a \u{XXXX} escape, where pos
+// is the position of its backslash.
+func (l *Lexer) readUnicodeEscape(pos token.Position, out *strings.Builder) bool {
+	if l.peekChar() != '{' {
+		l.addError(pos, diagnostic.InvalidEscape, `\u needs a code point in braces, like \u{1F480}`)
+		return false
+	}
+	l.readChar()
+
+	var digits strings.Builder
+	for isHexDigit(l.peekChar()) {
+		l.readChar()
+		digits.WriteRune(l.ch)
+	}
+	if l.peekChar() != '}' || digits.Len() == 0 || digits.Len() > 6 {
+		l.addError(pos, diagnostic.InvalidEscape, `\u{...} needs 1 to 6 hex digits and a closing }`)
+		return false
+	}
+	l.readChar()
+
+	code, _ := strconv.ParseUint(digits.String(), 16, 32)
+	if !utf8.ValidRune(rune(code)) {
+		l.addError(pos, diagnostic.InvalidEscape, fmt.Sprintf(`\u{%s} is not a valid code point`, digits.String()))
+		return false
+	}
+	out.WriteRune(rune(code))
+	return true
+}
+
+// isHexDigit checks if a character is a hexadecimal digit.
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+// isBinaryDigit checks if a character is a binary digit.
+func isBinaryDigit(ch rune) bool {
+	return ch == '0' || ch == '1'
+}
+
+// isOctalDigit checks if a character is an octal digit.
+func isOctalDigit(ch rune) bool {
+	return '0' <= ch && ch <= '7'
+}
+
+// isASCIILetter checks if a character is a letter from a to z, in either case.
+func isASCIILetter(ch rune) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z'
+}
+
+// isLetter checks if a character can start an identifier: a Unicode letter,
+// an underscore or an emoji.
+func isLetter(ch rune) bool {
+	return unicode.IsLetter(ch) || ch == '_' || isEmoji(ch)
+}
+
+// isIdentifierPart checks if a character can continue an identifier. Besides
+// what isLetter allows, that's the combining marks of accented letters and
+// the joiners and variation selectors inside emoji sequences like 🧑‍💻.
+func isIdentifierPart(ch rune) bool {
+	return isLetter(ch) || unicode.In(ch, unicode.Mn, unicode.Mc) ||
+		ch == '\u200d' || ch == '\ufe0f'
+}
+
+// isEmoji checks if a character is a pictographic emoji. This is a meme
+// language, so 💀 is a perfectly good variable name. The blocks are the ones
+// emoji come from: flag letters like 🇯, pictographs like 🔥, the misc
+// technical block for ⌚, misc symbols and dingbats for ☕, and the arrows
+// and stars block for ⭐.
+func isEmoji(ch rune) bool {
+	return 0x1f1e6 <= ch && ch <= 0x1f1ff || 0x1f300 <= ch && ch <= 0x1faff ||
+		0x2300 <= ch && ch <= 0x23ff || 0x2600 <= ch && ch <= 0x27bf ||
+		0x2b00 <= ch && ch <= 0x2bff
 }
 
 // isDigit checks if a character is a digit, used to identify numeric literals.
-func isDigit(ch byte) bool {
+func isDigit(ch rune) bool {
 	return '0' <= ch && ch <= '9'
 }
 
 // newToken creates a new token with the given type and literal value.
-func newToken(tokenType token.TokenType, ch byte) token.Token {
+func newToken(tokenType token.TokenType, ch rune) token.Token {
 	return token.Token{Type: tokenType, Literal: string(ch)}
 }
+
+// newTwoCharToken creates a token for a two-character operator such as '+=',
+// consuming the second character.
+func (l *Lexer) newTwoCharToken(tokenType token.TokenType) token.Token {
+	ch := l.ch
+	l.readChar()
+	return token.Token{Type: tokenType, Literal: string(ch) + string(l.ch)}
+}
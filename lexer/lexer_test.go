@@ -1,8 +1,13 @@
 package lexer
 
 import (
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
+	"github.com/Jitesh117/brainrotLang-interpreter/diagnostic"
 	"github.com/Jitesh117/brainrotLang-interpreter/token"
 )
 
@@ -13,7 +18,7 @@ yeet add = vibe(x, y) {
   x + y;
 };
 yeet result = add(five, ten);
-!-/*5;
+!-/ *5;
 5 < 10 > 5;
 fr (5 < 10) {
 	slay based;
@@ -132,3 +137,638 @@ fr (5 < 10) {
 		}
 	}
 }
+
+func TestNextTokenPositions(t *testing.T) {
+	input := `yeet x = 5;
+fr (x) {
+	"hi"
+}`
+	tests := []struct {
+		expectedLiteral string
+		expectedLine    int
+		expectedColumn  int
+	}{
+		{"yeet", 1, 1},
+		{"x", 1, 6},
+		{"=", 1, 8},
+		{"5", 1, 10},
+		{";", 1, 11},
+		{"fr", 2, 1},
+		{"(", 2, 4},
+		{"x", 2, 5},
+		{")", 2, 6},
+		{"{", 2, 8},
+		{"hi", 3, 2},
+		{"}", 4, 1},
+		{"", 4, 2},
+	}
+	l := NewFile("test.br", input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+		if tok.Pos.Line != tt.expectedLine || tok.Pos.Column != tt.expectedColumn {
+			t.Fatalf("tests[%d] - position wrong. expected=%d:%d, got=%d:%d",
+				i, tt.expectedLine, tt.expectedColumn, tok.Pos.Line, tok.Pos.Column)
+		}
+		if tok.Pos.Filename != "test.br" {
+			t.Fatalf("tests[%d] - filename wrong. expected=%q, got=%q",
+				i, "test.br", tok.Pos.Filename)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := `// fibonacci.br
+yeet x = 5; // five
+/* a block
+   comment */ x / 2`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "yeet"},
+		{token.IDENT, "x"},
+		{token.ASSIGN, "="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.SLASH, "/"},
+		{token.INT, "2"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+
+	l = New(input)
+	l.SetMode(ScanComments)
+	expectedComments := []struct {
+		literal string
+		line    int
+	}{
+		{"// fibonacci.br", 1},
+		{"// five", 2},
+		{"/* a block\n   comment */", 3},
+	}
+	var comments []token.Token
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		if tok.Type == token.COMMENT {
+			comments = append(comments, tok)
+		}
+	}
+	if len(comments) != len(expectedComments) {
+		t.Fatalf("wrong number of comments. expected=%d, got=%d", len(expectedComments), len(comments))
+	}
+	for i, c := range expectedComments {
+		if comments[i].Literal != c.literal || comments[i].Pos.Line != c.line {
+			t.Errorf("comments[%d] wrong. expected=%q on line %d, got=%q on line %d",
+				i, c.literal, c.line, comments[i].Literal, comments[i].Pos.Line)
+		}
+	}
+}
+
+func TestUnterminatedBlockComment(t *testing.T) {
+	l := New("yeet x = 5; /* oops")
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+	}
+
+	diagnostics := l.Diagnostics()
+	if len(diagnostics) != 1 {
+		t.Fatalf("wrong number of diagnostics. expected=1, got=%d", len(diagnostics))
+	}
+	if diagnostics[0].String() != "1:13: block comment is never closed, missing */" {
+		t.Errorf("wrong diagnostic. got=%q", diagnostics[0].String())
+	}
+}
+
+func TestCompoundOperators(t *testing.T) {
+	input := `x += 1; x -= 2; x *= 3; x /= 4; x %= 5; x % 6; x++; x--; - -x`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "x"}, {token.PLUS_ASSIGN, "+="}, {token.INT, "1"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.MINUS_ASSIGN, "-="}, {token.INT, "2"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.ASTERISK_ASSIGN, "*="}, {token.INT, "3"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.SLASH_ASSIGN, "/="}, {token.INT, "4"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.PERCENT_ASSIGN, "%="}, {token.INT, "5"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.PERCENT, "%"}, {token.INT, "6"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.INCREMENT, "++"}, {token.SEMICOLON, ";"},
+		{token.IDENT, "x"}, {token.DECREMENT, "--"}, {token.SEMICOLON, ";"},
+		{token.MINUS, "-"}, {token.MINUS, "-"}, {token.IDENT, "x"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestLogicalOperators(t *testing.T) {
+	input := `a && b || c & d`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "a"},
+		{token.AND, "&&"},
+		{token.IDENT, "b"},
+		{token.OR, "||"},
+		{token.IDENT, "c"},
+		{token.BIT_AND, "&"},
+		{token.IDENT, "d"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestComparisonAndBitwiseOperators(t *testing.T) {
+	input := `a <= b >= c ** d & e | f ^ g << h >> i *= j < k`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "a"}, {token.LT_EQ, "<="},
+		{token.IDENT, "b"}, {token.GT_EQ, ">="},
+		{token.IDENT, "c"}, {token.POWER, "**"},
+		{token.IDENT, "d"}, {token.BIT_AND, "&"},
+		{token.IDENT, "e"}, {token.BIT_OR, "|"},
+		{token.IDENT, "f"}, {token.BIT_XOR, "^"},
+		{token.IDENT, "g"}, {token.SHIFT_LEFT, "<<"},
+		{token.IDENT, "h"}, {token.SHIFT_RIGHT, ">>"},
+		{token.IDENT, "i"}, {token.ASTERISK_ASSIGN, "*="},
+		{token.IDENT, "j"}, {token.LT, "<"},
+		{token.IDENT, "k"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestEllipsis(t *testing.T) {
+	input := `vibe(a, ...rest) .. .`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.FUNCTION, "vibe"},
+		{token.LPAREN, "("},
+		{token.IDENT, "a"},
+		{token.COMMA, ","},
+		{token.ELLIPSIS, "..."},
+		{token.IDENT, "rest"},
+		{token.RPAREN, ")"},
+		{token.ILLEGAL, ".."},
+		{token.ILLEGAL, "."},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestFloatLiterals(t *testing.T) {
+	input := `3.14 1e-3 2E+10 6.02e23 0.5; 1.5.2 7. x`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.FLOAT, "3.14"},
+		{token.FLOAT, "1e-3"},
+		{token.FLOAT, "2E+10"},
+		{token.FLOAT, "6.02e23"},
+		{token.FLOAT, "0.5"},
+		{token.SEMICOLON, ";"},
+		{token.FLOAT, "1.5"},
+		{token.ILLEGAL, "."},
+		{token.INT, "2"},
+		{token.INT, "7"},
+		{token.ILLEGAL, "."},
+		{token.IDENT, "x"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+	if len(l.Diagnostics()) != 0 {
+		t.Errorf("unexpected diagnostics: %v", l.Diagnostics())
+	}
+}
+
+func TestMalformedExponent(t *testing.T) {
+	l := New("yeet x = 1e+;")
+	var illegal token.Token
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		if tok.Type == token.ILLEGAL {
+			illegal = tok
+		}
+	}
+
+	if illegal.Literal != "1e+" {
+		t.Errorf("wrong illegal token. got=%q", illegal.Literal)
+	}
+	diagnostics := l.Diagnostics()
+	if len(diagnostics) != 1 {
+		t.Fatalf("wrong number of diagnostics. expected=1, got=%d", len(diagnostics))
+	}
+	if diagnostics[0].String() != "1:10: malformed number 1e+, the exponent has no digits" {
+		t.Errorf("wrong diagnostic. got=%q", diagnostics[0].String())
+	}
+}
+
+func TestNumberLiteralForms(t *testing.T) {
+	input := `0xFF 0Xff 0b1010 0o17 1_000_000 0x_ff 3.141_592 1_0e1_0 0 07`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.INT, "0xFF"},
+		{token.INT, "0Xff"},
+		{token.INT, "0b1010"},
+		{token.INT, "0o17"},
+		{token.INT, "1_000_000"},
+		{token.ILLEGAL, "0x_ff"},
+		{token.FLOAT, "3.141_592"},
+		{token.FLOAT, "1_0e1_0"},
+		{token.INT, "0"},
+		{token.INT, "07"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+}
+
+func TestMalformedNumbers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"yeet x = 0xZZ;", "1:10: malformed number 0xZZ, Z is not a valid hex digit"},
+		{"yeet x = 0b102;", "1:10: malformed number 0b102, 2 is not a valid binary digit"},
+		{"yeet x = 0o78;", "1:10: malformed number 0o78, 8 is not a valid octal digit"},
+		{"yeet x = 0x;", "1:10: malformed number 0x, it needs hex digits after the prefix"},
+		{"yeet x = 1_000_;", "1:10: malformed number 1_000_, _ can only go between digits"},
+		{"yeet x = 1__000;", "1:10: malformed number 1__000, _ can only go between digits"},
+		{"yeet x = 1_.5;", "1:10: malformed number 1_.5, _ can only go between digits"},
+		{"yeet x = 0b1_;", "1:10: malformed number 0b1_, _ can only go between digits"},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		}
+
+		diagnostics := l.Diagnostics()
+		if len(diagnostics) != 1 {
+			t.Fatalf("wrong number of diagnostics for %q. expected=1, got=%v", tt.input, diagnostics)
+		}
+		if diagnostics[0].String() != tt.expected {
+			t.Errorf("wrong diagnostic. expected=%q, got=%q", tt.expected, diagnostics[0].String())
+		}
+		if diagnostics[0].Code != diagnostic.MalformedNumber {
+			t.Errorf("wrong code. expected=%s, got=%s", diagnostic.MalformedNumber, diagnostics[0].Code)
+		}
+	}
+}
+
+func TestStringEscapes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"a\nb"`, "a\nb"},
+		{`"tab\there"`, "tab\there"},
+		{`"\r\0"`, "\r\x00"},
+		{`"say \"hi\""`, `say "hi"`},
+		{`"back\\slash"`, `back\slash`},
+		{`"\u{48}\u{49}"`, "HI"},
+		{`"skull \u{1F480}"`, "skull \U0001F480"},
+		{`"\u{e9}t\u{E9}"`, "été"},
+		{"\"multi\nline\"", "multi\nline"},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+		if tok.Type != token.STRING || tok.Literal != tt.expected {
+			t.Errorf("wrong token for %s. expected=STRING %q, got=%s %q", tt.input, tt.expected, tok.Type, tok.Literal)
+		}
+		if len(l.Diagnostics()) != 0 {
+			t.Errorf("unexpected diagnostics for %s: %v", tt.input, l.Diagnostics())
+		}
+		if next := l.NextToken(); next.Type != token.EOF {
+			t.Errorf("expected EOF after %s, got=%s %q", tt.input, next.Type, next.Literal)
+		}
+	}
+}
+
+func TestInvalidStrings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+		nextType token.TokenType
+	}{
+		{`"bad \q escape"; x`, []string{"1:6: unknown escape sequence \\q in string"}, token.SEMICOLON},
+		{`"\u1F480"; x`, []string{`1:2: \u needs a code point in braces, like \u{1F480}`}, token.SEMICOLON},
+		{`"\u{}"; x`, []string{`1:2: \u{...} needs 1 to 6 hex digits and a closing }`}, token.SEMICOLON},
+		{`"\u{1234567}"; x`, []string{`1:2: \u{...} needs 1 to 6 hex digits and a closing }`}, token.SEMICOLON},
+		{`"\u{D800}"; x`, []string{`1:2: \u{D800} is not a valid code point`}, token.SEMICOLON},
+		{`"\q \w"; x`, []string{"1:2: unknown escape sequence \\q in string", "1:5: unknown escape sequence \\w in string"}, token.SEMICOLON},
+		{`yeet s = "never closed`, []string{`1:10: string is never closed, missing "`}, token.EOF},
+		{`"ends in a backslash\`, []string{`1:1: string is never closed, missing "`}, token.EOF},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+		for tok.Type != token.ILLEGAL && tok.Type != token.EOF {
+			tok = l.NextToken()
+		}
+		if tok.Type != token.ILLEGAL {
+			t.Errorf("expected ILLEGAL token for %s, got=%s", tt.input, tok.Type)
+			continue
+		}
+		if next := l.NextToken(); next.Type != tt.nextType {
+			t.Errorf("wrong token after %s. expected=%s, got=%s", tt.input, tt.nextType, next.Type)
+		}
+
+		var got []string
+		for _, d := range l.Diagnostics() {
+			got = append(got, d.String())
+		}
+		if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+			t.Errorf("wrong diagnostics for %s. expected=%q, got=%q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestInterpolatedStrings(t *testing.T) {
+	input := `f"hi {name}!" f"{ {"a": 1}["a"] } and {f"{x}"}" f"plain" f"\{x\}"`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.FSTRING_HEAD, "hi "},
+		{token.IDENT, "name"},
+		{token.FSTRING_TAIL, "!"},
+		{token.FSTRING_HEAD, ""},
+		{token.LBRACE, "{"},
+		{token.STRING, "a"},
+		{token.COLON, ":"},
+		{token.INT, "1"},
+		{token.RBRACE, "}"},
+		{token.LBRACKET, "["},
+		{token.STRING, "a"},
+		{token.RBRACKET, "]"},
+		{token.FSTRING_MIDDLE, " and "},
+		{token.FSTRING_HEAD, ""},
+		{token.IDENT, "x"},
+		{token.FSTRING_TAIL, ""},
+		{token.FSTRING_TAIL, ""},
+		{token.STRING, "plain"},
+		{token.STRING, "{x}"},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+	if len(l.Diagnostics()) != 0 {
+		t.Errorf("unexpected diagnostics: %v", l.Diagnostics())
+	}
+}
+
+func TestUnterminatedInterpolatedString(t *testing.T) {
+	l := New(`yeet s = f"a {x} b`)
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+	}
+
+	diagnostics := l.Diagnostics()
+	if len(diagnostics) != 1 {
+		t.Fatalf("wrong number of diagnostics. expected=1, got=%d", len(diagnostics))
+	}
+	if diagnostics[0].String() != `1:16: f-string is never closed, missing "` {
+		t.Errorf("wrong diagnostic. got=%q", diagnostics[0].String())
+	}
+}
+
+func TestRawStrings(t *testing.T) {
+	input := "`no \\n escapes` `two\r\nlines` `{\"json\": [1, 2]}`"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.STRING, `no \n escapes`},
+		{token.STRING, "two\nlines"},
+		{token.STRING, `{"json": [1, 2]}`},
+		{token.EOF, ""},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+	}
+
+	l = New("`a\nb` x")
+	l.NextToken()
+	if tok := l.NextToken(); tok.Pos.String() != "2:4" {
+		t.Errorf("wrong position after a multi-line raw string. got=%s", tok.Pos)
+	}
+
+	l = New("yeet s = `never closed\n")
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+	}
+	diagnostics := l.Diagnostics()
+	if len(diagnostics) != 1 || diagnostics[0].String() != "1:10: raw string is never closed, missing `" {
+		t.Errorf("wrong diagnostics. got=%v", diagnostics)
+	}
+}
+
+func TestUnicodeIdentifiers(t *testing.T) {
+	input := "yeet café = \"olé 💀\";\nyeet rizz💀 = naïve + 🔥;\n🧑‍💻 ☕️ 東京 _x ü @"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+		expectedPos     string
+	}{
+		{token.LET, "yeet", "1:1"},
+		{token.IDENT, "café", "1:6"},
+		{token.ASSIGN, "=", "1:11"},
+		{token.STRING, "olé 💀", "1:13"},
+		{token.SEMICOLON, ";", "1:20"},
+		{token.LET, "yeet", "2:1"},
+		{token.IDENT, "rizz💀", "2:6"},
+		{token.ASSIGN, "=", "2:12"},
+		{token.IDENT, "naïve", "2:14"},
+		{token.PLUS, "+", "2:20"},
+		{token.IDENT, "🔥", "2:22"},
+		{token.SEMICOLON, ";", "2:23"},
+		{token.IDENT, "🧑‍💻", "3:1"},
+		{token.IDENT, "☕️", "3:5"},
+		{token.IDENT, "東京", "3:8"},
+		{token.IDENT, "_x", "3:11"},
+		{token.IDENT, "ü", "3:14"},
+		{token.ILLEGAL, "@", "3:16"},
+		{token.EOF, "", "3:17"},
+	}
+	l := New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+		if tok.Pos.String() != tt.expectedPos {
+			t.Errorf("tests[%d] - position of %q wrong. expected=%s, got=%s",
+				i, tok.Literal, tt.expectedPos, tok.Pos)
+		}
+	}
+}
+
+func TestEmojiRanges(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected token.TokenType
+	}{
+		{"⭐", token.IDENT},
+		{"⌚", token.IDENT},
+		{"⬆️", token.IDENT},
+		{"🇯🇵", token.IDENT},
+		{"💀", token.IDENT},
+		{"\u22ff", token.ILLEGAL},
+		{"\u2300", token.IDENT},
+		{"\u23ff", token.IDENT},
+		{"\u2400", token.ILLEGAL},
+		{"\u2aff", token.ILLEGAL},
+		{"\u2b00", token.IDENT},
+		{"\u2bff", token.IDENT},
+		{"\U0001f1e5", token.ILLEGAL},
+		{"\U0001f1e6", token.IDENT},
+		{"\U0001f1ff", token.IDENT},
+		{"\U0001f200", token.ILLEGAL},
+	}
+
+	for _, tt := range tests {
+		tok := New(tt.input).NextToken()
+		if tok.Type != tt.expected {
+			t.Errorf("wrong token type for %U. expected=%s, got=%s %q",
+				[]rune(tt.input)[0], tt.expected, tok.Type, tok.Literal)
+			continue
+		}
+		if tt.expected == token.IDENT && tok.Literal != tt.input {
+			t.Errorf("wrong literal for %U. expected=%q, got=%q", []rune(tt.input)[0], tt.input, tok.Literal)
+		}
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	input := `yeet café = 0xFF_FF; // comment
+/* block
+comment */ vibe add(a, ...b) { slay a ** 2.5e-1; }
+f"{add(1)} 💀\n" ` + "`raw\\`" + ` {"k": [1, 2]}`
+
+	want := New(input)
+	want.SetMode(ScanComments)
+	// OneByteReader hides ReadRune, so the lexer has to buffer it itself
+	got := NewReader(iotest.OneByteReader(strings.NewReader(input)))
+	got.SetMode(ScanComments)
+	for i := 0; ; i++ {
+		expected, tok := want.NextToken(), got.NextToken()
+		if tok != expected {
+			t.Fatalf("tokens[%d] wrong. expected=%s %q at %s, got=%s %q at %s",
+				i, expected.Type, expected.Literal, expected.Pos, tok.Type, tok.Literal, tok.Pos)
+		}
+		if tok.Type == token.EOF {
+			break
+		}
+	}
+	if got.Err() != nil {
+		t.Errorf("unexpected error: %v", got.Err())
+	}
+}
+
+func TestNewReaderError(t *testing.T) {
+	errDisk := errors.New("disk is on fire")
+	r := io.MultiReader(strings.NewReader("yeet x"), iotest.ErrReader(errDisk))
+	l := NewFileReader("main.br", r)
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "yeet"},
+		{token.IDENT, "x"},
+		{token.EOF, ""},
+	}
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - token wrong. expected=%s %q, got=%s %q",
+				i, tt.expectedType, tt.expectedLiteral, tok.Type, tok.Literal)
+		}
+		if tok.Pos.Filename != "main.br" {
+			t.Fatalf("tests[%d] - filename wrong. got=%q", i, tok.Pos.Filename)
+		}
+	}
+	if l.Err() != errDisk {
+		t.Errorf("wrong error. expected=%v, got=%v", errDisk, l.Err())
+	}
+}
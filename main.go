@@ -1,14 +1,44 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"os/user"
 
+	"github.com/Jitesh117/brainrotLang-interpreter/object"
 	"github.com/Jitesh117/brainrotLang-interpreter/repl"
+	"github.com/Jitesh117/brainrotLang-interpreter/runner"
 )
 
+const usage = `Usage:
+  brainrot                     start the REPL
+  brainrot run <file.br>       run a script
+  brainrot run -e '<code>'     run code given on the command line
+  brainrot run [-]             run a script read from stdin
+
+Flags for run:
+  -checked                     report int64 overflow instead of using big integers
+`
+
 func main() {
+	args := os.Args[1:]
+	if len(args) == 0 {
+		startRepl()
+		return
+	}
+
+	switch args[0] {
+	case "run":
+		os.Exit(run(args[1:]))
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(os.Stdout, usage)
+	default:
+		os.Exit(run(args))
+	}
+}
+
+func startRepl() {
 	user, err := user.Current()
 	if err != nil {
 		panic(err)
@@ -21,3 +51,38 @@ func main() {
 
 	repl.Start(os.Stdin, os.Stdout)
 }
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	code := flags.String("e", "", "run `code` instead of a file")
+	checked := flags.Bool("checked", false, "report int64 overflow instead of using big integers")
+	if err := flags.Parse(args); err != nil {
+		return runner.ExitParseError
+	}
+
+	env := object.NewEnvironment()
+	env.SetChecked(*checked)
+	switch {
+	case *code != "":
+		if flags.NArg() != 0 {
+			flags.Usage()
+			return runner.ExitParseError
+		}
+		return runner.Run("-e", *code, env, os.Stdout, os.Stderr)
+	case flags.NArg() == 0 || flags.Arg(0) == "-":
+		return runner.RunReader("<stdin>", os.Stdin, env, os.Stdout, os.Stderr)
+	case flags.NArg() == 1:
+		f, err := os.Open(flags.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "bruh, couldn't read %s: %v\n", flags.Arg(0), err)
+			return runner.ExitRuntimeError
+		}
+		defer f.Close()
+		return runner.RunReader(flags.Arg(0), f, env, os.Stdout, os.Stderr)
+	default:
+		flags.Usage()
+		return runner.ExitParseError
+	}
+}
@@ -1,13 +1,16 @@
 package object
 
+import "sort"
+
 func NewEnvironment() *Environment {
 	s := make(map[string]Object)
 	return &Environment{store: s, outer: nil}
 }
 
 type Environment struct {
-	store map[string]Object
-	outer *Environment
+	store   map[string]Object
+	outer   *Environment
+	checked bool
 }
 
 func (e *Environment) Get(name string) (Object, bool) {
@@ -23,9 +26,51 @@ func (e *Environment) Set(name string, val Object) Object {
 	return val
 }
 
+// Assign rebinds name in the innermost scope that already defines it. It
+// reports false, changing nothing, if no scope defines name.
+func (e *Environment) Assign(name string, val Object) (Object, bool) {
+	if _, ok := e.store[name]; ok {
+		e.store[name] = val
+		return val, true
+	}
+	if e.outer != nil {
+		return e.outer.Assign(name, val)
+	}
+	return nil, false
+}
+
+// Checked reports whether integer arithmetic should report int64 overflow as
+// an error instead of switching to big integers. The mode is kept on the
+// outermost scope, so every vibe sees the mode of the run it is part of.
+func (e *Environment) Checked() bool {
+	for e.outer != nil {
+		e = e.outer
+	}
+	return e.checked
+}
+
+// SetChecked turns checked integer arithmetic on or off for the whole run.
+func (e *Environment) SetChecked(on bool) {
+	for e.outer != nil {
+		e = e.outer
+	}
+	e.checked = on
+}
+
 func NewEnclosedEnvironment(outer *Environment) *Environment {
 	env := NewEnvironment()
 	env.outer = outer
 
 	return env
 }
+
+// Names returns the names bound in this scope, sorted. Bindings in outer
+// scopes are not included.
+func (e *Environment) Names() []string {
+	names := make([]string, 0, len(e.store))
+	for name := range e.store {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
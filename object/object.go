@@ -4,15 +4,19 @@ import (
 	"bytes"
 	"fmt"
 	"hash/fnv"
+	"math/big"
+	"strconv"
 	"strings"
 
 	"github.com/Jitesh117/brainrotLang-interpreter/ast"
+	"github.com/Jitesh117/brainrotLang-interpreter/token"
 )
 
 type ObjectType string
 
 const (
 	INTEGER_OBJ      = "INTEGER"
+	FLOAT_OBJ        = "FLOAT"
 	BOOLEAN_OBJ      = "BOOLEAN"
 	NULL_OBJ         = "NULl"
 	RETURN_VALUE_OBJ = "RETURN_VALUE"
@@ -22,6 +26,8 @@ const (
 	BUILTIN_OBJ      = "BUILTIN"
 	ARRAY_OBJ        = "ARRAY"
 	HASH_OBJ         = "HASH"
+	GHOST_OBJ        = "GHOST"
+	NVM_OBJ          = "NVM"
 )
 
 type Object interface {
@@ -37,6 +43,33 @@ func (i *Integer) Inspect() string { return fmt.Sprintf("%d", i.Value) }
 
 func (i *Integer) Type() ObjectType { return INTEGER_OBJ }
 
+// BigInteger is an integer too big for an int64. Integer arithmetic
+// switches to it on overflow and back to Integer once the value fits again,
+// so the two never hold the same value.
+type BigInteger struct {
+	Value *big.Int
+}
+
+func (b *BigInteger) Inspect() string { return b.Value.String() }
+
+func (b *BigInteger) Type() ObjectType { return INTEGER_OBJ }
+
+type Float struct {
+	Value float64
+}
+
+// Inspect always shows a float as one, so 3.0 doesn't look like the
+// integer 3.
+func (f *Float) Inspect() string {
+	s := strconv.FormatFloat(f.Value, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eIN") {
+		s += ".0"
+	}
+	return s
+}
+
+func (f *Float) Type() ObjectType { return FLOAT_OBJ }
+
 type Boolean struct {
 	Value bool
 }
@@ -56,15 +89,38 @@ type SlayValue struct {
 func (rv *SlayValue) Type() ObjectType { return RETURN_VALUE_OBJ }
 func (rv *SlayValue) Inspect() string  { return rv.Value.Inspect() }
 
+// Ghost is the signal a `ghost` statement sends to break out of the
+// enclosing grind loop.
+type Ghost struct{}
+
+func (g *Ghost) Type() ObjectType { return GHOST_OBJ }
+func (g *Ghost) Inspect() string  { return "ghost" }
+
+// Nvm is the signal an `nvm` statement sends to skip to the next iteration
+// of the enclosing grind loop.
+type Nvm struct{}
+
+func (n *Nvm) Type() ObjectType { return NVM_OBJ }
+func (n *Nvm) Inspect() string  { return "nvm" }
+
 type Error struct {
 	Message string
+	Pos     token.Position // where in the source the error was raised
 }
 
 func (e *Error) Type() ObjectType { return ERROR_OBJ }
-func (e *Error) Inspect() string  { return "ERROR: " + e.Message }
+func (e *Error) Inspect() string {
+	if e.Pos.IsValid() {
+		return "ERROR: " + e.Pos.String() + ": " + e.Message
+	}
+	return "ERROR: " + e.Message
+}
 
 type Vibe struct {
+	Name       string
 	Parameters []*ast.Identifier
+	Defaults   []ast.Expression
+	Rest       *ast.Identifier
 	Body       *ast.BlockStatement
 	Env        *Environment
 }
@@ -73,15 +129,13 @@ func (v *Vibe) Type() ObjectType { return FUNCTION_OBJ }
 func (v *Vibe) Inspect() string {
 	var out bytes.Buffer
 
-	params := []string{}
-	for _, p := range v.Parameters {
-		params = append(params, p.String())
-	}
-
 	out.WriteString("vibe")
-	out.WriteString("()")
-	out.WriteString(strings.Join(params, ", "))
-	out.WriteString(") {\n}")
+	if v.Name != "" {
+		out.WriteString(" " + v.Name)
+	}
+	out.WriteString("(")
+	out.WriteString(ast.ParameterList(v.Parameters, v.Defaults, v.Rest))
+	out.WriteString(") {\n")
 	out.WriteString(v.Body.String())
 	out.WriteString("\n}")
 
@@ -140,6 +194,21 @@ func (i *Integer) HashKey() HashKey {
 	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
 }
 
+// bigIntegerKey tags the hash keys of integers too big for an int64, so they
+// can't land on the same key as an Integer.
+const bigIntegerKey ObjectType = "BIG_INTEGER"
+
+func (b *BigInteger) HashKey() HashKey {
+	// A value that fits in an int64 must find the same entry as the Integer
+	if b.Value.IsInt64() {
+		return (&Integer{Value: b.Value.Int64()}).HashKey()
+	}
+	h := fnv.New64a()
+	h.Write(b.Value.Append(nil, 10))
+
+	return HashKey{Type: bigIntegerKey, Value: h.Sum64()}
+}
+
 func (s *String) HashKey() HashKey {
 	h := fnv.New64a()
 	h.Write([]byte(s.Value))
@@ -1,6 +1,9 @@
 package object
 
-import "testing"
+import (
+	"math/big"
+	"testing"
+)
 
 func TestStringHashKey(t *testing.T) {
 	hello1 := &String{Value: "Hello World"}
@@ -17,3 +20,29 @@ func TestStringHashKey(t *testing.T) {
 		t.Errorf("strings with different content have same hash keys")
 	}
 }
+
+func TestBigIntegerHashKey(t *testing.T) {
+	a, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	b, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	c, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+
+	if (&BigInteger{Value: a}).HashKey() != (&BigInteger{Value: b}).HashKey() {
+		t.Errorf("big integers with same value have different hash keys")
+	}
+	if (&BigInteger{Value: a}).HashKey() == (&BigInteger{Value: c}).HashKey() {
+		t.Errorf("big integers with different values have same hash keys")
+	}
+}
+
+func TestIntegerAndBigIntegerHashKeys(t *testing.T) {
+	small := &BigInteger{Value: big.NewInt(42)}
+	if small.HashKey() != (&Integer{Value: 42}).HashKey() {
+		t.Errorf("big integer that fits in an int64 has a different hash key from the integer")
+	}
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	key := (&BigInteger{Value: huge}).HashKey()
+	if key == (&Integer{Value: int64(key.Value)}).HashKey() {
+		t.Errorf("big integer shares its hash key with an integer")
+	}
+}
@@ -1,10 +1,14 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/Jitesh117/brainrotLang-interpreter/ast"
+	"github.com/Jitesh117/brainrotLang-interpreter/diagnostic"
 	"github.com/Jitesh117/brainrotLang-interpreter/lexer"
 	"github.com/Jitesh117/brainrotLang-interpreter/token"
 )
@@ -12,53 +16,95 @@ import (
 const (
 	_ int = iota
 	LOWEST
+	ASSIGN      // x = y
+	LOGICAL_OR  // ||
+	LOGICAL_AND // &&
 	EQUALS      // ==
 	LESSGREATER // < or <
+	BIT_OR      // |
+	BIT_XOR     // ^
+	BIT_AND     // &
+	SHIFT       // << or >>
 	SUM         // +
 	PRODUCT     // *
 	PREFIX      // -X or !X
+	POWER       // X ** Y
+	POSTFIX     // X++ or X--
 	CALL        // myFunction(X)
 	INDEX       // array[index]
 )
 
 var precedences = map[token.TokenType]int{
-	token.EQ:       EQUALS,
-	token.NOT_EQ:   EQUALS,
-	token.LT:       LESSGREATER,
-	token.GT:       LESSGREATER,
-	token.PLUS:     SUM,
-	token.MINUS:    SUM,
-	token.SLASH:    PRODUCT,
-	token.ASTERISK: PRODUCT,
-	token.LPAREN:   CALL,
-	token.LBRACKET: INDEX,
+	token.ASSIGN:          ASSIGN,
+	token.PLUS_ASSIGN:     ASSIGN,
+	token.MINUS_ASSIGN:    ASSIGN,
+	token.ASTERISK_ASSIGN: ASSIGN,
+	token.SLASH_ASSIGN:    ASSIGN,
+	token.PERCENT_ASSIGN:  ASSIGN,
+	token.PERCENT:         PRODUCT,
+	token.INCREMENT:       POSTFIX,
+	token.DECREMENT:       POSTFIX,
+	token.OR:              LOGICAL_OR,
+	token.AND:             LOGICAL_AND,
+	token.EQ:              EQUALS,
+	token.NOT_EQ:          EQUALS,
+	token.LT:              LESSGREATER,
+	token.GT:              LESSGREATER,
+	token.LT_EQ:           LESSGREATER,
+	token.GT_EQ:           LESSGREATER,
+	token.BIT_OR:          BIT_OR,
+	token.BIT_XOR:         BIT_XOR,
+	token.BIT_AND:         BIT_AND,
+	token.SHIFT_LEFT:      SHIFT,
+	token.SHIFT_RIGHT:     SHIFT,
+	token.PLUS:            SUM,
+	token.MINUS:           SUM,
+	token.SLASH:           PRODUCT,
+	token.ASTERISK:        PRODUCT,
+	token.POWER:           POWER,
+	token.LPAREN:          CALL,
+	token.LBRACKET:        INDEX,
 }
 
 type Parser struct {
 	l *lexer.Lexer
 
-	errors    []string
-	curToken  token.Token
-	peekToken token.Token
+	diagnostics []diagnostic.Diagnostic
+	curToken    token.Token
+	peekToken   token.Token
+
+	// lexerErrors counts the lexer diagnostics already copied into diagnostics.
+	lexerErrors int
+
+	// loopDepth counts the grind loops around the current statement, so
+	// ghost and nvm can be rejected outside of one. Vibe bodies reset it.
+	loopDepth int
+
+	// panicking is set by the first error in a statement and suppresses
+	// follow-on errors until synchronize finds the next statement.
+	panicking bool
 
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
 }
 
 func New(l *lexer.Lexer) *Parser {
-	p := &Parser{l: l, errors: []string{}}
+	p := &Parser{l: l, diagnostics: []diagnostic.Diagnostic{}}
 
 	p.prefixParseFns = make(map[token.TokenType]prefixParseFn)
 	p.registerPrefix(token.IDENT, p.parseIdentifier)
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
+	p.registerPrefix(token.FLOAT, p.parseFloatLiteral)
 	p.registerPrefix(token.BANG, p.parsePrefixExpression)
 	p.registerPrefix(token.MINUS, p.parsePrefixExpression)
+	p.registerPrefix(token.DECREMENT, p.parseDoubleNegation)
 	p.registerPrefix(token.TRUE, p.parseBoolean)
 	p.registerPrefix(token.FALSE, p.parseBoolean)
 	p.registerPrefix(token.LPAREN, p.parseGroupedExpression)
 	p.registerPrefix(token.IF, p.parseIfExpression)
 	p.registerPrefix(token.FUNCTION, p.parseFunctionLiteral)
 	p.registerPrefix(token.STRING, p.parseStringLiteral)
+	p.registerPrefix(token.FSTRING_HEAD, p.parseInterpolatedString)
 	p.registerPrefix(token.LBRACKET, p.parseArrayLiteral)
 	p.registerPrefix(token.LBRACE, p.parseHashLiteral)
 
@@ -68,12 +114,31 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.MINUS, p.parseInfixExpression)
 	p.registerInfix(token.SLASH, p.parseInfixExpression)
 	p.registerInfix(token.ASTERISK, p.parseInfixExpression)
+	p.registerInfix(token.AND, p.parseInfixExpression)
+	p.registerInfix(token.OR, p.parseInfixExpression)
 	p.registerInfix(token.EQ, p.parseInfixExpression)
 	p.registerInfix(token.NOT_EQ, p.parseInfixExpression)
 	p.registerInfix(token.LT, p.parseInfixExpression)
 	p.registerInfix(token.GT, p.parseInfixExpression)
+	p.registerInfix(token.LT_EQ, p.parseInfixExpression)
+	p.registerInfix(token.GT_EQ, p.parseInfixExpression)
+	p.registerInfix(token.POWER, p.parseInfixExpression)
+	p.registerInfix(token.BIT_AND, p.parseInfixExpression)
+	p.registerInfix(token.BIT_OR, p.parseInfixExpression)
+	p.registerInfix(token.BIT_XOR, p.parseInfixExpression)
+	p.registerInfix(token.SHIFT_LEFT, p.parseInfixExpression)
+	p.registerInfix(token.SHIFT_RIGHT, p.parseInfixExpression)
 	p.registerInfix(token.LPAREN, p.parseCallExpression)
 	p.registerInfix(token.LBRACKET, p.parseIndexExpression)
+	p.registerInfix(token.PERCENT, p.parseInfixExpression)
+	p.registerInfix(token.ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.PLUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.MINUS_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.ASTERISK_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.SLASH_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.PERCENT_ASSIGN, p.parseAssignExpression)
+	p.registerInfix(token.INCREMENT, p.parsePostfixExpression)
+	p.registerInfix(token.DECREMENT, p.parsePostfixExpression)
 
 	// Read two tokens, so curToken and peekToken are both set
 
@@ -100,6 +165,31 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 	return &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
 }
 
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	str := &ast.InterpolatedString{Token: p.curToken}
+	str.Parts = append(str.Parts, &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal})
+
+	for !p.curTokenIs(token.FSTRING_TAIL) {
+		p.nextToken()
+		if p.curTokenIs(token.FSTRING_MIDDLE) || p.curTokenIs(token.FSTRING_TAIL) {
+			p.addError(p.curToken, diagnostic.EmptyPlaceholder, nil, "empty {} in f-string, put an expression in there")
+			return nil
+		}
+		str.Parts = append(str.Parts, p.parseExpression(LOWEST))
+
+		if !p.peekTokenIs(token.FSTRING_MIDDLE) && !p.peekTokenIs(token.FSTRING_TAIL) {
+			msg := fmt.Sprintf("expected } to close the f-string placeholder, got %s instead", p.peekToken.Type)
+			expected := []token.TokenType{token.FSTRING_MIDDLE, token.FSTRING_TAIL}
+			p.addError(p.peekToken, diagnostic.UnexpectedToken, expected, msg)
+			return nil
+		}
+		p.nextToken()
+		str.Parts = append(str.Parts, &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal})
+	}
+
+	return str
+}
+
 func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
 	exp := &ast.CallExpression{Token: p.curToken, Function: function}
 	exp.Arguments = p.parseExpressionList(token.RPAREN)
@@ -132,40 +222,76 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 		return nil
 	}
 
-	lit.Parameters = p.parseFunctionParameters()
+	if !p.parseFunctionParameters(lit) {
+		return nil
+	}
 
 	if !p.expectPeek(token.LBRACE) {
 		return nil
 	}
 
-	lit.Body = p.parseBlockStatement()
+	lit.Body = p.parseVibeBody()
 
 	return lit
 }
 
-func (p *Parser) parseFunctionParameters() []*ast.Identifier {
-	identifiers := []*ast.Identifier{}
+// parseVibeBody parses the block of a vibe. Loops around the vibe don't
+// count inside it, so a ghost there can't break out of the caller's loop.
+func (p *Parser) parseVibeBody() *ast.BlockStatement {
+	loopDepth := p.loopDepth
+	p.loopDepth = 0
+	body := p.parseBlockStatement()
+	p.loopDepth = loopDepth
+	return body
+}
+
+// parseFunctionParameters fills in the parameters of vibe: plain names,
+// then names with a default value, then an optional ...rest.
+func (p *Parser) parseFunctionParameters(vibe *ast.VibeLiteral) bool {
+	vibe.Parameters = []*ast.Identifier{}
 
 	if p.peekTokenIs(token.RPAREN) {
 		p.nextToken()
-		return identifiers
+		return true
 	}
 
-	p.nextToken()
+	hasDefaults := false
+	for {
+		if p.peekTokenIs(token.ELLIPSIS) {
+			p.nextToken()
+			if !p.expectPeek(token.IDENT) {
+				return false
+			}
+			vibe.Rest = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			break
+		}
 
-	ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
-	identifiers = append(identifiers, ident)
-	for p.peekTokenIs(token.COMMA) {
-		p.nextToken()
-		p.nextToken()
+		if !p.expectPeek(token.IDENT) {
+			return false
+		}
 		ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
-		identifiers = append(identifiers, ident)
 
+		var def ast.Expression
+		if p.peekTokenIs(token.ASSIGN) {
+			p.nextToken()
+			p.nextToken()
+			def = p.parseExpression(LOWEST)
+			hasDefaults = true
+		} else if hasDefaults {
+			msg := fmt.Sprintf("parameter %s needs a default, the ones before it have one", ident.Value)
+			p.addError(p.curToken, diagnostic.InvalidParameter, nil, msg)
+			return false
+		}
+		vibe.Parameters = append(vibe.Parameters, ident)
+		vibe.Defaults = append(vibe.Defaults, def)
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
 	}
-	if !p.expectPeek(token.RPAREN) {
-		return nil
-	}
-	return identifiers
+
+	return p.expectPeek(token.RPAREN)
 }
 
 func (p *Parser) parseGroupedExpression() ast.Expression {
@@ -187,12 +313,72 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	}
 
 	precedence := p.curPrecedence()
+	if p.curTokenIs(token.POWER) {
+		precedence-- // right-associative, so 2 ** 3 ** 2 is 2 ** (3 ** 2)
+	}
 	p.nextToken()
 	expression.Right = p.parseExpression(precedence)
 
 	return expression
 }
 
+func (p *Parser) parseAssignExpression(target ast.Expression) ast.Expression {
+	expression := &ast.AssignExpression{
+		Token:    p.curToken,
+		Target:   target,
+		Operator: p.curToken.Literal,
+	}
+	if !p.checkAssignable(target) {
+		return nil
+	}
+
+	// Parse the value one level lower so x = y = 1 groups as x = (y = 1)
+	p.nextToken()
+	expression.Value = p.parseExpression(ASSIGN - 1)
+
+	return expression
+}
+
+func (p *Parser) parsePostfixExpression(left ast.Expression) ast.Expression {
+	expression := &ast.PostfixExpression{
+		Token:    p.curToken,
+		Left:     left,
+		Operator: p.curToken.Literal,
+	}
+	if !p.checkAssignable(left) {
+		return nil
+	}
+	// x--5 used to mean x - -5, so rather than quietly reading it as x--
+	// followed by a new statement 5, ask for spaces or a ;
+	next := p.peekToken
+	if expression.Operator == "--" && next.Pos.Offset == p.curToken.End.Offset {
+		switch next.Type {
+		case token.INT, token.FLOAT, token.IDENT, token.LPAREN:
+			msg := fmt.Sprintf("expected ; after %s--, got %s instead, put spaces around the minus signs to subtract a negative",
+				left.String(), next.Type)
+			p.addError(next, diagnostic.UnexpectedToken, []token.TokenType{token.SEMICOLON}, msg)
+			return nil
+		}
+	}
+	return expression
+}
+
+// checkAssignable reports an error at the current operator unless target is
+// something a value can be stored in.
+func (p *Parser) checkAssignable(target ast.Expression) bool {
+	if p.panicking {
+		return false // target is incomplete and the error is already reported
+	}
+	switch target.(type) {
+	case *ast.Identifier, *ast.IndexExpression:
+		return true
+	default:
+		msg := fmt.Sprintf("can't assign to %s, only to a name or an index", target.String())
+		p.addError(p.curToken, diagnostic.InvalidAssignment, nil, msg)
+		return false
+	}
+}
+
 func (p *Parser) parsePrefixExpression() ast.Expression {
 	expression := &ast.PrefixExpression{
 		Token:    p.curToken,
@@ -206,19 +392,70 @@ func (p *Parser) parsePrefixExpression() ast.Expression {
 	return expression
 }
 
+// parseDoubleNegation parses a prefix --, which the lexer reads as a single
+// token, as two minus signs, so --x still means -(-x).
+func (p *Parser) parseDoubleNegation() ast.Expression {
+	outer := p.curToken
+	outer.Type, outer.Literal = token.MINUS, "-"
+	inner := outer
+	inner.Pos.Offset++
+	inner.Pos.Column++
+	outer.End = inner.Pos
+
+	p.nextToken()
+
+	right := p.parseExpression(PREFIX)
+	return &ast.PrefixExpression{
+		Token:    outer,
+		Operator: "-",
+		Right:    &ast.PrefixExpression{Token: inner, Operator: "-", Right: right},
+	}
+}
+
+// Errors returns the parser diagnostics formatted as "position: message".
 func (p *Parser) Errors() []string {
-	return p.errors
+	errors := make([]string, 0, len(p.diagnostics))
+	for _, d := range p.diagnostics {
+		errors = append(errors, d.String())
+	}
+	return errors
+}
+
+// Diagnostics returns everything the parser reported, in source order.
+func (p *Parser) Diagnostics() []diagnostic.Diagnostic {
+	return p.diagnostics
+}
+
+func (p *Parser) addError(tok token.Token, code diagnostic.Code, expected []token.TokenType, msg string) {
+	if p.panicking {
+		return
+	}
+	p.panicking = true
+	p.diagnostics = append(p.diagnostics, diagnostic.Diagnostic{
+		Severity: diagnostic.Error,
+		Code:     code,
+		Span:     diagnostic.SpanOf(tok),
+		Expected: expected,
+		Actual:   tok.Type,
+		Message:  msg,
+	})
 }
 
 func (p *Parser) peekError(t token.TokenType) {
 	msg := fmt.Sprintf("expected next token to be %s, got %s instead",
 		t, p.peekToken.Type)
-	p.errors = append(p.errors, msg)
+	p.addError(p.peekToken, diagnostic.UnexpectedToken, []token.TokenType{t}, msg)
 }
 
 func (p *Parser) nextToken() {
 	p.curToken = p.peekToken
 	p.peekToken = p.l.NextToken()
+
+	// Pick up anything the lexer reported while reading peekToken
+	if lexed := p.l.Diagnostics(); len(lexed) > p.lexerErrors {
+		p.diagnostics = append(p.diagnostics, lexed[p.lexerErrors:]...)
+		p.lexerErrors = len(lexed)
+	}
 }
 
 func (p *Parser) ParseProgram() *ast.Program {
@@ -227,7 +464,15 @@ func (p *Parser) ParseProgram() *ast.Program {
 	// build the child nodes, i.e. the "statements"
 	program.Statements = []ast.Statement{}
 	for p.curToken.Type != token.EOF {
+		start := p.curToken
 		stmt := p.parseStatement()
+		if p.panicking {
+			p.synchronize(start)
+			if p.curTokenIs(token.RBRACE) {
+				p.nextToken() // a stray `}` with no block to close
+			}
+			continue
+		}
 		if stmt != nil {
 			program.Statements = append(program.Statements, stmt)
 		}
@@ -236,6 +481,45 @@ func (p *Parser) ParseProgram() *ast.Program {
 	return program
 }
 
+// synchronize discards the tokens of a statement that failed to parse and
+// leaves curToken on the first token of the next statement. It stops after a
+// `;` or after the `}` of a block opened while skipping, before a `yeet`,
+// `slay` or `grind` other than start, and before a `}` closing the enclosing
+// block.
+func (p *Parser) synchronize(start token.Token) {
+	p.panicking = false
+	depth := 0
+
+	for !p.curTokenIs(token.EOF) {
+		switch p.curToken.Type {
+		case token.SEMICOLON:
+			if depth == 0 {
+				p.nextToken()
+				return
+			}
+		case token.LBRACE:
+			depth++
+		case token.RBRACE:
+			if depth == 0 {
+				return
+			}
+			depth--
+			if depth == 0 {
+				p.nextToken()
+				if p.curTokenIs(token.SEMICOLON) {
+					p.nextToken()
+				}
+				return
+			}
+		case token.LET, token.RETURN, token.WHILE:
+			if depth == 0 && p.curToken != start {
+				return
+			}
+		}
+		p.nextToken()
+	}
+}
+
 func (p *Parser) parseIdentifier() ast.Expression {
 	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 }
@@ -254,6 +538,17 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseYeetStatement()
 	case token.RETURN:
 		return p.parseSlayStatement()
+	case token.WHILE:
+		return p.parseGrindStatement()
+	case token.BREAK:
+		return p.parseGhostStatement()
+	case token.CONTINUE:
+		return p.parseNvmStatement()
+	case token.FUNCTION:
+		if p.peekTokenIs(token.IDENT) {
+			return p.parseVibeStatement()
+		}
+		return p.parseExpressionStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -272,12 +567,123 @@ func (p *Parser) parseYeetStatement() *ast.YeetStatement {
 	p.nextToken()
 
 	stmt.Value = p.parseExpression(LOWEST)
-	for !p.curTokenIs(token.SEMICOLON) {
+	if vibe, ok := stmt.Value.(*ast.VibeLiteral); ok {
+		vibe.Name = stmt.Name.Value
+	}
+	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
 	return stmt
 }
 
+func (p *Parser) parseVibeStatement() *ast.VibeStatement {
+	stmt := &ast.VibeStatement{Token: p.curToken}
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	stmt.Vibe = &ast.VibeLiteral{Token: stmt.Token, Name: stmt.Name.Value}
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	if !p.parseFunctionParameters(stmt.Vibe) {
+		return nil
+	}
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+	stmt.Vibe.Body = p.parseVibeBody()
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+func (p *Parser) parseGrindStatement() ast.Statement {
+	tok := p.curToken
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	p.nextToken()
+	if p.curTokenIs(token.IDENT) && (p.peekTokenIs(token.IN) || p.peekTokenIs(token.COMMA)) {
+		return p.parseGrindInStatement(tok)
+	}
+
+	stmt := &ast.GrindStatement{Token: tok}
+	stmt.Condition = p.parseExpression(LOWEST)
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	p.loopDepth++
+	stmt.Body = p.parseBlockStatement()
+	p.loopDepth--
+
+	return stmt
+}
+
+// parseGrindInStatement parses the rest of `grind (x in xs) { ... }` or
+// `grind (k, v in xs) { ... }`, starting at the first name.
+func (p *Parser) parseGrindInStatement(tok token.Token) *ast.GrindInStatement {
+	stmt := &ast.GrindInStatement{Token: tok}
+	stmt.Value = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	if p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		stmt.Key = stmt.Value
+		stmt.Value = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+	if !p.expectPeek(token.IN) {
+		return nil
+	}
+	p.nextToken()
+	stmt.Iterable = p.parseExpression(LOWEST)
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	p.loopDepth++
+	stmt.Body = p.parseBlockStatement()
+	p.loopDepth--
+
+	return stmt
+}
+
+func (p *Parser) parseGhostStatement() *ast.GhostStatement {
+	stmt := &ast.GhostStatement{Token: p.curToken}
+	p.checkInLoop()
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+func (p *Parser) parseNvmStatement() *ast.NvmStatement {
+	stmt := &ast.NvmStatement{Token: p.curToken}
+	p.checkInLoop()
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+func (p *Parser) checkInLoop() {
+	if p.loopDepth == 0 {
+		msg := fmt.Sprintf("%s outside of a grind loop, that's not how this works", p.curToken.Literal)
+		p.addError(p.curToken, diagnostic.LoopControlOutsideLoop, nil, msg)
+	}
+}
+
 func (p *Parser) curTokenIs(t token.TokenType) bool {
 	return p.curToken.Type == t
 }
@@ -342,10 +748,39 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{Token: p.curToken}
 
-	value, err := strconv.ParseInt(p.curToken.Literal, 0, 64)
+	// Base 0 understands the 0x, 0b and 0o prefixes and _ separators, but it
+	// would also read a plain 017 as octal, so decimals are parsed in base 10
+	literal, base := p.curToken.Literal, 0
+	if len(literal) < 2 || !strings.ContainsRune("xXbBoO", rune(literal[1])) {
+		literal, base = strings.ReplaceAll(literal, "_", ""), 10
+	}
+
+	value, err := strconv.ParseInt(literal, base, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		// Too big for an int64, so it becomes a big integer
+		if n, ok := new(big.Int).SetString(literal, base); ok {
+			lit.Big = n
+			return lit
+		}
+	}
 	if err != nil {
 		msg := fmt.Sprintf("could not parse %q as IntegerLiteral", p.curToken.Literal)
-		p.errors = append(p.errors, msg)
+		p.addError(p.curToken, diagnostic.InvalidInteger, nil, msg)
+		return nil
+	}
+
+	lit.Value = value
+
+	return lit
+}
+
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{Token: p.curToken}
+
+	value, err := strconv.ParseFloat(strings.ReplaceAll(p.curToken.Literal, "_", ""), 64)
+	if err != nil {
+		msg := fmt.Sprintf("could not parse %q as FloatLiteral", p.curToken.Literal)
+		p.addError(p.curToken, diagnostic.InvalidFloat, nil, msg)
 		return nil
 	}
 
@@ -360,8 +795,25 @@ type (
 )
 
 func (p *Parser) noPrefixParseFnError(t token.TokenType) {
+	// The lexer already explained what's wrong with an illegal token it
+	// reported, so don't pile a second error on top of it
+	if t == token.ILLEGAL && p.lexerReported(p.curToken) {
+		p.panicking = true
+		return
+	}
 	msg := fmt.Sprintf("no prefix parse function for %s found", t)
-	p.errors = append(p.errors, msg)
+	p.addError(p.curToken, diagnostic.NoPrefixParseFn, nil, msg)
+}
+
+// lexerReported reports whether the lexer flagged a problem anywhere in
+// tok, which ends where the token after it starts.
+func (p *Parser) lexerReported(tok token.Token) bool {
+	for _, d := range p.l.Diagnostics() {
+		if d.Span.Start.Offset >= tok.Pos.Offset && d.Span.Start.Offset < p.peekToken.Pos.Offset {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *Parser) peekPrecedence() int {
@@ -415,7 +867,13 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	block.Statements = []ast.Statement{}
 	p.nextToken()
 	for !p.curTokenIs(token.RBRACE) && !p.curTokenIs(token.EOF) {
+		start := p.curToken
+		recovering := p.panicking // the enclosing statement already failed
 		stmt := p.parseStatement()
+		if p.panicking && !recovering {
+			p.synchronize(start)
+			continue
+		}
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
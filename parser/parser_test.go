@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/Jitesh117/brainrotLang-interpreter/ast"
+	"github.com/Jitesh117/brainrotLang-interpreter/diagnostic"
 	"github.com/Jitesh117/brainrotLang-interpreter/lexer"
+	"github.com/Jitesh117/brainrotLang-interpreter/token"
 )
 
 func TestYeetStatements(t *testing.T) {
@@ -242,6 +244,71 @@ func testIntegerLiteral(t *testing.T, il ast.Expression, value int64) bool {
 	return true
 }
 
+func TestPrefixDecrementIsDoubleNegation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"--5;", "(-(-5))"},
+		{"--x * 2;", "((-(-x)) * 2)"},
+		{"-(--x);", "(-(-(-x)))"},
+		{"x - --y;", "(x - (-(-y)))"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestAmbiguousPostfixDecrement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x--5", "1:4: expected ; after x--, got INT instead, put spaces around the minus signs to subtract a negative"},
+		{"x--y", "1:4: expected ; after x--, got IDENT instead, put spaces around the minus signs to subtract a negative"},
+		{"x--1.5", "1:4: expected ; after x--, got FLOAT instead, put spaces around the minus signs to subtract a negative"},
+		{"x--(1)", "1:4: expected ; after x--, got ( instead, put spaces around the minus signs to subtract a negative"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("wrong errors for %q. expected=%q, got=%q", tt.input, tt.expected, errors)
+		}
+	}
+
+	// With a space, a newline or ++ there is nothing to mix up
+	fine := []string{
+		"x-- 5",
+		"x--\n5",
+		"x++5",
+		"n++ fr (based) { n }",
+		"n++ vibe f() { 1 }",
+		"n++ m++",
+	}
+	for _, input := range fine {
+		l := lexer.New(input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		if len(program.Statements) != 2 {
+			t.Errorf("wrong number of statements for %q. expected=2, got=%d", input, len(program.Statements))
+		}
+	}
+}
+
 func TestParsingInfixExpressions(t *testing.T) {
 	infixTests := []struct {
 		input      string
@@ -856,7 +923,7 @@ func TestParsingHashLiteralsStringKeys(t *testing.T) {
 		if !ok {
 			t.Errorf("key is not ast.StringLiteral. got=%T", key)
 		}
-		expectedValue := expected[literal.String()]
+		expectedValue := expected[literal.Value]
 		testIntegerLiteral(t, value, expectedValue)
 	}
 }
@@ -910,11 +977,723 @@ func TestParsingHashLiteralsWithExpressions(t *testing.T) {
 			t.Errorf("key is not ast.StringLiteral. got=%T", key)
 			continue
 		}
-		testFunc, ok := tests[literal.String()]
+		testFunc, ok := tests[literal.Value]
 		if !ok {
+			t.Errorf("No test function for key %q found", literal.Value)
 			continue
-			t.Errorf("No test function for key %q found", literal.String())
 		}
 		testFunc(value)
 	}
 }
+
+func TestParserErrorPositions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"yeet x 5;", "1:8: expected next token to be =, got INT instead"},
+		{"yeet x = 5;\nyeet = 10;", "2:6: expected next token to be IDENT, got = instead"},
+		{"add(1, 2;", "1:9: expected next token to be ), got ; instead"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Fatalf("expected parser errors for %q, got none", tt.input)
+		}
+		if errors[0] != tt.expected {
+			t.Errorf("wrong error. expected=%q, got=%q", tt.expected, errors[0])
+		}
+	}
+}
+
+func TestParserDiagnostics(t *testing.T) {
+	l := lexer.New("yeet x 5;")
+	p := New(l)
+	p.ParseProgram()
+
+	diagnostics := p.Diagnostics()
+	if len(diagnostics) == 0 {
+		t.Fatalf("expected diagnostics, got none")
+	}
+	d := diagnostics[0]
+	if d.Code != diagnostic.UnexpectedToken {
+		t.Errorf("d.Code wrong. expected=%q, got=%q", diagnostic.UnexpectedToken, d.Code)
+	}
+	if len(d.Expected) != 1 || d.Expected[0] != token.ASSIGN {
+		t.Errorf("d.Expected wrong. expected=%v, got=%v", []token.TokenType{token.ASSIGN}, d.Expected)
+	}
+	if d.Actual != token.INT {
+		t.Errorf("d.Actual wrong. expected=%q, got=%q", token.INT, d.Actual)
+	}
+	if d.Span.Start.Column != 8 || d.Span.End.Column != 9 {
+		t.Errorf("d.Span wrong. got=%d-%d", d.Span.Start.Column, d.Span.End.Column)
+	}
+	if d.Message != "expected next token to be =, got INT instead" {
+		t.Errorf("d.Message wrong. got=%q", d.Message)
+	}
+}
+
+func TestStringDiagnosticSpan(t *testing.T) {
+	// The escapes make the source much longer than the string's value
+	l := lexer.New(`yeet "\u{1F480}\n" = 5;`)
+	p := New(l)
+	p.ParseProgram()
+
+	diagnostics := p.Diagnostics()
+	if len(diagnostics) == 0 {
+		t.Fatalf("expected diagnostics, got none")
+	}
+	d := diagnostics[0]
+	if d.Span.Start.Column != 6 || d.Span.End.Column != 19 {
+		t.Errorf("d.Span wrong. expected=6-19, got=%d-%d", d.Span.Start.Column, d.Span.End.Column)
+	}
+	if d.Span.End.Offset-d.Span.Start.Offset != 13 {
+		t.Errorf("d.Span offsets wrong. got=%d-%d", d.Span.Start.Offset, d.Span.End.Offset)
+	}
+}
+
+func TestParserErrorRecovery(t *testing.T) {
+	tests := []struct {
+		input              string
+		expectedErrors     []string
+		expectedStatements []string
+	}{
+		{
+			"yeet x = 5;\nyeet y = (x + ;\nyeet z = x * 2;\nz;",
+			[]string{"2:15: no prefix parse function for ; found"},
+			[]string{"yeet x = 5;", "yeet z = (x * 2);", "z"},
+		},
+		{
+			"yeet = 5; yeet a = 1; a",
+			[]string{"1:6: expected next token to be IDENT, got = instead"},
+			[]string{"yeet a = 1;", "a"},
+		},
+		{
+			"fr (x) { yeet w = ; x } yeet b = 2;",
+			[]string{"1:19: no prefix parse function for ; found"},
+			[]string{"ifx x", "yeet b = 2;"},
+		},
+		{
+			"yeet f = vibe(x { x }; slay 3;",
+			[]string{"1:17: expected next token to be ), got { instead"},
+			[]string{"slay 3;"},
+		},
+		{
+			"yeet x = 1 }; x",
+			[]string{"1:12: no prefix parse function for } found"},
+			[]string{"yeet x = 1;", "x"},
+		},
+		{
+			"yeet x = ",
+			[]string{"1:10: no prefix parse function for EOF found"},
+			[]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != len(tt.expectedErrors) {
+			t.Errorf("wrong number of errors for %q. expected=%q, got=%q",
+				tt.input, tt.expectedErrors, errors)
+			continue
+		}
+		for i, msg := range tt.expectedErrors {
+			if errors[i] != msg {
+				t.Errorf("errors[%d] wrong. expected=%q, got=%q", i, msg, errors[i])
+			}
+		}
+
+		if len(program.Statements) != len(tt.expectedStatements) {
+			t.Errorf("wrong number of statements for %q. expected=%d, got=%d (%q)",
+				tt.input, len(tt.expectedStatements), len(program.Statements), program.String())
+			continue
+		}
+		for i, stmt := range program.Statements {
+			if stmt.String() != tt.expectedStatements[i] {
+				t.Errorf("statements[%d] wrong. expected=%q, got=%q",
+					i, tt.expectedStatements[i], stmt.String())
+			}
+		}
+	}
+}
+
+func TestLexerErrorsAreReported(t *testing.T) {
+	l := lexer.New("yeet x = 5; // fine\nx /* never closed")
+	p := New(l)
+	program := p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("wrong number of errors. expected=1, got=%q", errors)
+	}
+	if errors[0] != "2:3: block comment is never closed, missing */" {
+		t.Errorf("wrong error. got=%q", errors[0])
+	}
+	if len(program.Statements) != 2 {
+		t.Errorf("wrong number of statements. expected=2, got=%d", len(program.Statements))
+	}
+}
+
+func TestVibeStatementParsing(t *testing.T) {
+	input := `vibe add(a, b) { slay a + b; }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statements. got=%d",
+			len(program.Statements))
+	}
+	stmt, ok := program.Statements[0].(*ast.VibeStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.VibeStatement. got=%T",
+			program.Statements[0])
+	}
+	if !testIdentifier(t, stmt.Name, "add") {
+		return
+	}
+	if len(stmt.Vibe.Parameters) != 2 {
+		t.Fatalf("vibe parameters wrong. want 2, got=%d", len(stmt.Vibe.Parameters))
+	}
+	testLiteralExpression(t, stmt.Vibe.Parameters[0], "a")
+	testLiteralExpression(t, stmt.Vibe.Parameters[1], "b")
+
+	if stmt.Vibe.Name != "add" {
+		t.Errorf("stmt.Vibe.Name wrong. want %q, got=%q", "add", stmt.Vibe.Name)
+	}
+	if stmt.String() != "vibe add(a, b) slay (a + b);" {
+		t.Errorf("stmt.String() wrong. got=%q", stmt.String())
+	}
+}
+
+func TestGrindStatementParsing(t *testing.T) {
+	input := `grind (x < 10) { fr (x == 5) { ghost; } nvm; }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statements. got=%d",
+			len(program.Statements))
+	}
+	stmt, ok := program.Statements[0].(*ast.GrindStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.GrindStatement. got=%T",
+			program.Statements[0])
+	}
+	if !testInfixExpression(t, stmt.Condition, "x", "<", 10) {
+		return
+	}
+	if len(stmt.Body.Statements) != 2 {
+		t.Fatalf("body does not contain 2 statements. got=%d", len(stmt.Body.Statements))
+	}
+	if _, ok := stmt.Body.Statements[1].(*ast.NvmStatement); !ok {
+		t.Errorf("body.Statements[1] is not ast.NvmStatement. got=%T", stmt.Body.Statements[1])
+	}
+	if stmt.String() != "grind((x < 10)) if(x == 5) ghost;nvm;" {
+		t.Errorf("stmt.String() wrong. got=%q", stmt.String())
+	}
+}
+
+func TestLoopControlOutsideLoop(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"ghost;", []string{"1:1: ghost outside of a grind loop, that's not how this works"}},
+		{"fr (x) { nvm; }", []string{"1:10: nvm outside of a grind loop, that's not how this works"}},
+		{"grind (x) { vibe() { ghost; } }", []string{"1:22: ghost outside of a grind loop, that's not how this works"}},
+		{"grind (x) { grind (y) { ghost; } nvm; }", []string{}},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != len(tt.expected) {
+			t.Errorf("wrong errors for %q. expected=%q, got=%q", tt.input, tt.expected, errors)
+			continue
+		}
+		for i, msg := range tt.expected {
+			if errors[i] != msg {
+				t.Errorf("errors[%d] wrong. expected=%q, got=%q", i, msg, errors[i])
+			}
+		}
+	}
+}
+
+func TestGrindInStatementParsing(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedKey   string
+		expectedValue string
+		expectedStr   string
+	}{
+		{"grind (x in xs) { x }", "", "x", "grind(x in xs) x"},
+		{"grind (k, v in {}) { ghost; }", "k", "v", "grind(k, v in {}) ghost;"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt, ok := program.Statements[0].(*ast.GrindInStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.GrindInStatement. got=%T",
+				program.Statements[0])
+		}
+		if tt.expectedKey == "" && stmt.Key != nil {
+			t.Errorf("stmt.Key is not nil. got=%q", stmt.Key)
+		}
+		if tt.expectedKey != "" {
+			testIdentifier(t, stmt.Key, tt.expectedKey)
+		}
+		testIdentifier(t, stmt.Value, tt.expectedValue)
+		if stmt.String() != tt.expectedStr {
+			t.Errorf("stmt.String() wrong. expected=%q, got=%q", tt.expectedStr, stmt.String())
+		}
+	}
+}
+
+func TestAssignExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x = 5;", "(x = 5)"},
+		{"x = y = 1 + 2;", "(x = (y = (1 + 2)))"},
+		{"arr[0] = x * 2;", "((arr[0]) = (x * 2))"},
+		{`h["k"] = f(1) == 2;`, "((h[\"k\"]) = (f(1) == 2))"},
+		{"x += 1;", "(x += 1)"},
+		{"x -= y *= 2;", "(x -= (y *= 2))"},
+		{"arr[i] %= 3 + 4;", "((arr[i]) %= (3 + 4))"},
+		{"x /= 2 % 3;", "(x /= (2 % 3))"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		if _, ok := stmt.Expression.(*ast.AssignExpression); !ok {
+			t.Fatalf("stmt.Expression is not ast.AssignExpression. got=%T", stmt.Expression)
+		}
+		if stmt.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, stmt.String())
+		}
+	}
+}
+
+func TestInvalidAssignmentTarget(t *testing.T) {
+	l := lexer.New("a + b = 5; 1 = 2; f() += 1; 5++;")
+	p := New(l)
+	p.ParseProgram()
+
+	expected := []string{
+		"1:7: can't assign to (a + b), only to a name or an index",
+		"1:14: can't assign to 1, only to a name or an index",
+		"1:23: can't assign to f(), only to a name or an index",
+		"1:30: can't assign to 5, only to a name or an index",
+	}
+	errors := p.Errors()
+	if len(errors) != len(expected) {
+		t.Fatalf("wrong errors. expected=%q, got=%q", expected, errors)
+	}
+	for i, msg := range expected {
+		if errors[i] != msg {
+			t.Errorf("errors[%d] wrong. expected=%q, got=%q", i, msg, errors[i])
+		}
+	}
+}
+
+func TestPostfixExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x++;", "(x++)"},
+		{"arr[0]--;", "((arr[0])--)"},
+		{"-x++ + 1;", "((-(x++)) + 1)"},
+		{"x++ * 2;", "((x++) * 2)"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestLogicalOperatorPrecedence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a || b && c", "(a || (b && c))"},
+		{"a && b || c && d", "((a && b) || (c && d))"},
+		{"a == 1 && b < 2", "((a == 1) && (b < 2))"},
+		{"!a || b", "((!a) || b)"},
+		{"x = a || b", "(x = (a || b))"},
+		{"a || b || c", "((a || b) || c)"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestArithmeticAndBitwisePrecedence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"2 ** 3 ** 2", "(2 ** (3 ** 2))"},
+		{"-2 ** 2", "(-(2 ** 2))"},
+		{"2 * 3 ** 2", "(2 * (3 ** 2))"},
+		{"2 ** -1", "(2 ** (-1))"},
+		{"a <= b == c >= d", "((a <= b) == (c >= d))"},
+		{"a | b ^ c & d", "(a | (b ^ (c & d)))"},
+		{"a & b << 1 + 2", "(a & (b << (1 + 2)))"},
+		{"a & 1 == 0", "((a & 1) == 0)"},
+		{"a << 1 < b", "((a << 1) < b)"},
+		{"a >> 1 >> 2", "((a >> 1) >> 2)"},
+		{"a % 2 ** 3", "(a % (2 ** 3))"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestVibeLiteralNames(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"yeet add = vibe(a, b) { a + b };", "add"},
+		{"vibe(a, b) { a + b };", ""},
+		{"yeet f = g(vibe() { 1 });", ""},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		var vibe *ast.VibeLiteral
+		switch stmt := program.Statements[0].(type) {
+		case *ast.YeetStatement:
+			if call, ok := stmt.Value.(*ast.CallExpression); ok {
+				vibe = call.Arguments[0].(*ast.VibeLiteral)
+			} else {
+				vibe = stmt.Value.(*ast.VibeLiteral)
+			}
+		case *ast.ExpressionStatement:
+			vibe = stmt.Expression.(*ast.VibeLiteral)
+		}
+		if vibe.Name != tt.expected {
+			t.Errorf("vibe.Name wrong for %q. want %q, got=%q", tt.input, tt.expected, vibe.Name)
+		}
+	}
+}
+
+func TestDefaultAndRestParameterParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"vibe(a, b = 10) { a + b };", "vibe(a, b = 10) (a + b)"},
+		{"vibe(a = 1 + 2, b = a) { b };", "vibe(a = (1 + 2), b = a) b"},
+		{"vibe(...xs) { xs };", "vibe(...xs) xs"},
+		{"vibe(a, b = 2, ...xs) { xs };", "vibe(a, b = 2, ...xs) xs"},
+		{"vibe f(x, y = [1]) { x }", "vibe f(x, y = [1]) x"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestInvalidParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"vibe(a = 1, b) { b }", "1:13: parameter b needs a default, the ones before it have one"},
+		{"vibe(...xs, y) { y }", "1:11: expected next token to be ), got , instead"},
+		{"vibe(...) { 1 }", "1:9: expected next token to be IDENT, got ) instead"},
+		{"vibe(1) { 1 }", "1:6: expected next token to be IDENT, got INT instead"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 || errors[0] != tt.expected {
+			t.Errorf("wrong errors for %q. expected first=%q, got=%q", tt.input, tt.expected, errors)
+		}
+	}
+}
+
+func TestFloatLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"3.14;", 3.14},
+		{"1e-3;", 0.001},
+		{"2.5E2;", 250},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.FloatLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.FloatLiteral. got=%T", stmt.Expression)
+		}
+		if literal.Value != tt.expected {
+			t.Errorf("literal.Value not %g. got=%g", tt.expected, literal.Value)
+		}
+		if literal.String()+";" != tt.input {
+			t.Errorf("literal.String() wrong. got=%q", literal.String())
+		}
+	}
+}
+
+func TestNumberLiteralForms(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0xff;", 255},
+		{"0XFF_FF;", 65535},
+		{"0b1010;", 10},
+		{"0o17;", 15},
+		{"1_000_000;", 1000000},
+		{"017;", 17},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.IntegerLiteral. got=%T", stmt.Expression)
+		}
+		if literal.Value != tt.expected {
+			t.Errorf("literal.Value for %q not %d. got=%d", tt.input, tt.expected, literal.Value)
+		}
+	}
+}
+
+func TestMalformedNumberIsReportedOnce(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"yeet x = 1e; x", "1:10: malformed number 1e, the exponent has no digits"},
+		{"yeet x = 1e999;", "1:10: could not parse \"1e999\" as FloatLiteral"},
+		{"yeet x = 0xZZ; x", "1:10: malformed number 0xZZ, Z is not a valid hex digit"},
+		{"yeet x = 1_000_; x", "1:10: malformed number 1_000_, _ can only go between digits"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("wrong errors for %q. expected=%q, got=%q", tt.input, tt.expected, errors)
+		}
+	}
+}
+
+func TestInvalidStringIsReportedOnce(t *testing.T) {
+	tests := []struct {
+		input              string
+		expected           string
+		expectedStatements int
+	}{
+		{`yeet s = "bad \q"; yeet t = 1;`, "1:15: unknown escape sequence \\q in string", 1},
+		{`yeet t = 1; puts("oops`, `1:18: string is never closed, missing "`, 1},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("wrong errors for %s. expected=%q, got=%q", tt.input, tt.expected, errors)
+		}
+		if len(program.Statements) != tt.expectedStatements {
+			t.Errorf("wrong number of statements for %s. expected=%d, got=%d",
+				tt.input, tt.expectedStatements, len(program.Statements))
+		}
+	}
+}
+
+func TestInterpolatedStringParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`f"hi {name}!"`, `f"hi {name}!"`},
+		{`f"{a + b * 2} = {c}"`, `f"{(a + (b * 2))} = {c}"`},
+		{`f"{xs[0]}\n\{not a placeholder\}"`, `f"{(xs[0])}\n\{not a placeholder\}"`},
+		{`f"outer {f"inner {x}"}"`, `f"outer {f"inner {x}"}"`},
+		{`yeet s = f"{1}" + "!";`, `yeet s = (f"{1}" + "!");`},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	l := lexer.New(`f"a{x}b{y}c"`)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	str, ok := program.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.InterpolatedString)
+	if !ok {
+		t.Fatalf("exp not *ast.InterpolatedString. got=%T", program.Statements[0])
+	}
+	if len(str.Parts) != 5 {
+		t.Fatalf("wrong number of parts. want 5, got=%d", len(str.Parts))
+	}
+	for i, want := range []string{"a", "x", "b", "y", "c"} {
+		switch part := str.Parts[i].(type) {
+		case *ast.StringLiteral:
+			if part.Value != want {
+				t.Errorf("part %d wrong. want %q, got=%q", i, want, part.Value)
+			}
+		default:
+			testIdentifier(t, part, want)
+		}
+	}
+}
+
+func TestInvalidInterpolatedStrings(t *testing.T) {
+	tests := []struct {
+		input        string
+		expected     string
+		expectedCode diagnostic.Code
+	}{
+		{`f"a {} b"`, "1:6: empty {} in f-string, put an expression in there", diagnostic.EmptyPlaceholder},
+		{`f"a {x y} b"`, "1:8: expected } to close the f-string placeholder, got IDENT instead", diagnostic.UnexpectedToken},
+		{`f"a {x`, "1:7: expected } to close the f-string placeholder, got EOF instead", diagnostic.UnexpectedToken},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 || errors[0] != tt.expected {
+			t.Errorf("wrong errors for %s. expected=%q, got=%q", tt.input, tt.expected, errors)
+			continue
+		}
+		if code := p.Diagnostics()[0].Code; code != tt.expectedCode {
+			t.Errorf("wrong code for %s. expected=%s, got=%s", tt.input, tt.expectedCode, code)
+		}
+	}
+}
+
+func TestStringLiteralsRoundTrip(t *testing.T) {
+	tests := []string{
+		"yeet art = `\n /\\_/\\\n( o.o )\n`;",
+		`yeet s = "quote \" and \\ and \t";`,
+		"yeet j = `{\"k\": \"v\"}`;",
+		`yeet f = f"{name}: \{ \"quoted\" \}";`,
+		`yeet z = "two\nlines\0and a nul";`,
+	}
+
+	for _, input := range tests {
+		l := lexer.New(input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		printed := program.String()
+		l = lexer.New(printed)
+		p = New(l)
+		reparsed := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if reparsed.String() != printed {
+			t.Errorf("String() doesn't round-trip for %s. printed=%s, reprinted=%s", input, printed, reparsed.String())
+		}
+		first := program.Statements[0].(*ast.YeetStatement).Value
+		again := reparsed.Statements[0].(*ast.YeetStatement).Value
+		if lit, ok := first.(*ast.StringLiteral); ok && lit.Value != again.(*ast.StringLiteral).Value {
+			t.Errorf("value changed for %s. was=%q, now=%q", input, lit.Value, again.(*ast.StringLiteral).Value)
+		}
+	}
+}
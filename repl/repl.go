@@ -4,47 +4,195 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 
+	"github.com/Jitesh117/brainrotLang-interpreter/ast"
+	"github.com/Jitesh117/brainrotLang-interpreter/diagnostic"
 	"github.com/Jitesh117/brainrotLang-interpreter/evaluator"
 	"github.com/Jitesh117/brainrotLang-interpreter/object"
 	"github.com/Jitesh117/brainrotLang-interpreter/parser"
+	"github.com/Jitesh117/brainrotLang-interpreter/runner"
+	"github.com/Jitesh117/brainrotLang-interpreter/token"
 
 	"github.com/Jitesh117/brainrotLang-interpreter/lexer"
 )
 
-const PROMPT = ">>"
+const (
+	PROMPT              = ">>"
+	CONTINUATION_PROMPT = ".."
+)
 
 func Start(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
 	env := object.NewEnvironment()
 
+	var input strings.Builder
 	for {
-		fmt.Printf(PROMPT)
+		if input.Len() == 0 {
+			fmt.Fprint(out, PROMPT)
+		} else {
+			fmt.Fprint(out, CONTINUATION_PROMPT)
+		}
 		scanned := scanner.Scan()
 		if !scanned {
 			return
 		}
 		line := scanner.Text()
-		l := lexer.New(line)
-		p := parser.New(l)
 
+		if input.Len() == 0 && strings.HasPrefix(line, ":") {
+			env = runCommand(out, line, env)
+			continue
+		}
+
+		// A blank line while continuing gives up waiting for the rest of
+		// the statement and lets the parser report what's missing.
+		if input.Len() == 0 || strings.TrimSpace(line) != "" {
+			input.WriteString(line)
+			input.WriteString("\n")
+			if isIncomplete(input.String()) {
+				continue
+			}
+		}
+
+		src := input.String()
+		input.Reset()
+		runner.RunInteractive(src, env, out)
+	}
+}
+
+const help = `Commands:
+  :env            list the bindings in the session
+  :tokens <code>  show the tokens the lexer produces for code
+  :ast <code>     show the syntax tree the parser builds for code
+  :type <expr>    evaluate expr and show the type of its value
+  :load <file>    run a file in the session
+  :reset          forget all bindings and start a fresh session
+  :checked on|off report int64 overflow as an error, or use big integers
+  :help           show this message
+`
+
+// runCommand executes a colon-prefixed REPL command and returns the
+// environment the session should continue with.
+func runCommand(out io.Writer, line string, env *object.Environment) *object.Environment {
+	name, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	arg = strings.TrimSpace(arg)
+
+	switch name {
+	case ":env":
+		for _, name := range env.Names() {
+			val, _ := env.Get(name)
+			fmt.Fprintf(out, "%s = %s\n", name, val.Inspect())
+		}
+	case ":tokens":
+		l := lexer.New(arg)
+		l.SetMode(lexer.ScanComments)
+		for tok := l.NextToken(); ; tok = l.NextToken() {
+			fmt.Fprintf(out, "%s %s %q\n", tok.Pos, tok.Type, tok.Literal)
+			if tok.Type == token.EOF {
+				break
+			}
+		}
+	case ":ast":
+		p := parser.New(lexer.New(arg))
 		program := p.ParseProgram()
 		if len(p.Errors()) != 0 {
-			printParserErrors(out, p.Errors())
-			continue
+			printParserErrors(out, arg, p.Diagnostics())
+			break
+		}
+		ast.Fprint(out, program)
+	case ":type":
+		p := parser.New(lexer.New(arg))
+		program := p.ParseProgram()
+		if len(p.Errors()) != 0 {
+			printParserErrors(out, arg, p.Diagnostics())
+			break
 		}
 		evaluated := evaluator.Eval(program, env)
-		if evaluated != nil {
+		if evaluated == nil {
+			fmt.Fprintln(out, "no value")
+		} else if evaluated.Type() == object.ERROR_OBJ {
+			fmt.Fprintln(out, evaluated.Inspect())
+		} else {
+			fmt.Fprintln(out, evaluated.Type())
+		}
+	case ":load":
+		f, err := os.Open(arg)
+		if err != nil {
+			fmt.Fprintf(out, "bruh, couldn't read %s: %v\n", arg, err)
+			break
+		}
+		runner.RunReader(arg, f, env, out, out)
+		f.Close()
+	case ":reset":
+		fresh := object.NewEnvironment()
+		fresh.SetChecked(env.Checked())
+		return fresh
+	case ":checked":
+		switch arg {
+		case "on":
+			env.SetChecked(true)
+		case "off":
+			env.SetChecked(false)
+		case "":
+		default:
+			fmt.Fprintf(out, "bruh, :checked wants on or off, got %s\n", arg)
+			return env
+		}
+		if env.Checked() {
+			fmt.Fprintln(out, "checked arithmetic is on, int64 overflow is an error")
+		} else {
+			fmt.Fprintln(out, "checked arithmetic is off, integers grow as big as they need")
+		}
+	case ":help":
+		io.WriteString(out, help)
+	default:
+		fmt.Fprintf(out, "unknown command %s, try :help\n", name)
+	}
+	return env
+}
 
-			io.WriteString(out, evaluated.Inspect())
-			io.WriteString(out, "\n")
+// isIncomplete reports whether src stops in the middle of a statement: a
+// bracket is still open, a string has no closing quote, or the last token
+// is an operator still waiting for its right-hand side.
+func isIncomplete(src string) bool {
+	l := lexer.New(src)
+
+	depth := 0
+	var last token.Token
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		switch tok.Type {
+		case token.LPAREN, token.LBRACE, token.LBRACKET, token.FSTRING_HEAD:
+			depth++
+		case token.RPAREN, token.RBRACE, token.RBRACKET, token.FSTRING_TAIL:
+			depth--
 		}
+		last = tok
+	}
+	if depth > 0 {
+		return true
+	}
+	for _, d := range l.Diagnostics() {
+		if d.Code == diagnostic.UnterminatedComment || d.Code == diagnostic.UnterminatedString {
+			return true
+		}
+	}
 
+	switch last.Type {
+	case token.ASSIGN, token.PLUS, token.MINUS, token.BANG, token.ASTERISK,
+		token.SLASH, token.PERCENT, token.POWER, token.LT, token.GT,
+		token.LT_EQ, token.GT_EQ, token.EQ, token.NOT_EQ, token.AND, token.OR,
+		token.BIT_AND, token.BIT_OR, token.BIT_XOR, token.SHIFT_LEFT, token.SHIFT_RIGHT,
+		token.PLUS_ASSIGN, token.MINUS_ASSIGN, token.ASTERISK_ASSIGN,
+		token.SLASH_ASSIGN, token.PERCENT_ASSIGN,
+		token.COMMA, token.COLON:
+		return true
 	}
+	return false
 }
 
-func printParserErrors(out io.Writer, errors []string) {
-	for _, msg := range errors {
-		io.WriteString(out, "\t"+msg+"\n")
+func printParserErrors(out io.Writer, source string, diagnostics []diagnostic.Diagnostic) {
+	for _, d := range diagnostics {
+		diagnostic.Render(out, source, d)
 	}
 }
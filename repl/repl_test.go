@@ -0,0 +1,103 @@
+package repl
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestIsIncomplete(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"yeet x = 5;", false},
+		{"yeet add = vibe(a, b) {", true},
+		{"yeet add = vibe(a, b) {\n slay a + b;\n};", false},
+		{"fr (x > 1", true},
+		{"[1, 2,", true},
+		{"[1, 2]", false},
+		{"yeet s = \"hello", true},
+		{"yeet s = \"", true},
+		{"yeet s = \"hello\"", false},
+		{"yeet s = \"say \\\"hi", true},
+		{"yeet s = \"say \\\"hi\\\"\"", false},
+		{"yeet s = \"bad \\q\"", false},
+		{"yeet s = `raw", true},
+		{"yeet s = `raw\nstill raw`", false},
+		{"yeet s = f\"a {x", true},
+		{"yeet s = f\"a {x} b", true},
+		{"yeet s = f\"a {x} b\"", false},
+		{"yeet s = f\"a {  {\"k\": 1}", true},
+		{"yeet x = 1 +", true},
+		{"yeet x =", true},
+		{"{\"a\":", true},
+		{"}", false},
+		{"yeet x = 1; /* still", true},
+		{"yeet x = 1; /* done */", false},
+		{"yeet x = 1; // \"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isIncomplete(tt.input); got != tt.expected {
+			t.Errorf("isIncomplete(%q) wrong. expected=%t, got=%t", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestStartMultiLineInput(t *testing.T) {
+	input := `yeet add = vibe(a, b) {
+  slay a + b;
+};
+add(1,
+  2)
+yeet s = "two
+lines";
+s
+`
+	var out bytes.Buffer
+	Start(strings.NewReader(input), &out)
+
+	expected := ">>....>>..3\n>>..>>two\nlines\n>>"
+	if out.String() != expected {
+		t.Errorf("wrong output. expected=%q, got=%q", expected, out.String())
+	}
+}
+
+func TestCommands(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{":env", ""},
+		{"yeet b = 2; yeet a = 1;\n:env", "a = 1\nb = 2\n"},
+		{":tokens yeet x", "1:1 LET \"yeet\"\n1:6 IDENT \"x\"\n1:7 EOF \"\"\n"},
+		{":ast 1 + 2", "Program 1:1\n" +
+			"  Statements[0]: ExpressionStatement 1:1\n" +
+			"    Expression: InfixExpression 1:3 Operator=\"+\"\n" +
+			"      Left: IntegerLiteral 1:1 Value=1\n" +
+			"      Right: IntegerLiteral 1:5 Value=2\n"},
+		{":type [1]", "ARRAY\n"},
+		{"yeet s = \"hi\";\n:type s", "STRING\n"},
+		{"yeet a = 1;\n:reset\n:env", ""},
+		{":checked", "checked arithmetic is off, integers grow as big as they need\n"},
+		{":checked on\n9223372036854775807 + 1", "checked arithmetic is on, int64 overflow is an error\n" +
+			"ERROR: 1:21: integer overflow, it's giving too much: 9223372036854775807 + 1\n"},
+		{":checked on\n:checked off\n9223372036854775807 + 1", "checked arithmetic is on, int64 overflow is an error\n" +
+			"checked arithmetic is off, integers grow as big as they need\n9223372036854775808\n"},
+		{":checked on\n:reset\n:checked", "checked arithmetic is on, int64 overflow is an error\n" +
+			"checked arithmetic is on, int64 overflow is an error\n"},
+		{":checked maybe", "bruh, :checked wants on or off, got maybe\n"},
+		{":nope", "unknown command :nope, try :help\n"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		Start(strings.NewReader(tt.input), &out)
+
+		got := strings.ReplaceAll(out.String(), PROMPT, "")
+		if got != tt.expected {
+			t.Errorf("wrong output for %q. expected=%q, got=%q", tt.input, tt.expected, got)
+		}
+	}
+}
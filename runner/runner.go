@@ -0,0 +1,163 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Jitesh117/brainrotLang-interpreter/diagnostic"
+	"github.com/Jitesh117/brainrotLang-interpreter/evaluator"
+	"github.com/Jitesh117/brainrotLang-interpreter/lexer"
+	"github.com/Jitesh117/brainrotLang-interpreter/object"
+	"github.com/Jitesh117/brainrotLang-interpreter/parser"
+)
+
+// Exit codes returned by Run.
+const (
+	ExitOK           = 0
+	ExitRuntimeError = 1
+	ExitParseError   = 2
+)
+
+// Run lexes, parses and evaluates src as a whole program in env. Parser
+// diagnostics and runtime errors are written to errOut, and the value the
+// program ends with, if any, is written to out. It returns the exit code.
+func Run(filename, src string, env *object.Environment, out, errOut io.Writer) int {
+	return RunReader(filename, strings.NewReader(src), env, out, errOut)
+}
+
+// RunReader is like Run, but lexes the program straight from r as it is
+// read. Only the last few lines are kept for showing parser diagnostics, and
+// when r can seek, such as a file, the lines diagnostics point at are read
+// again instead.
+func RunReader(filename string, r io.Reader, env *object.Environment, out, errOut io.Writer) int {
+	return run(filename, r, env, out, errOut, false)
+}
+
+// RunInteractive runs src in env the way the REPL does: everything is written
+// to out, and a null result is shown rather than skipped.
+func RunInteractive(src string, env *object.Environment, out io.Writer) int {
+	return run("", strings.NewReader(src), env, out, out, true)
+}
+
+func run(filename string, r io.Reader, env *object.Environment, out, errOut io.Writer, showNull bool) int {
+	recent := newRecentLines(keepLines)
+	l := lexer.NewFileReader(filename, io.TeeReader(r, recent))
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+	if err := l.Err(); err != nil {
+		fmt.Fprintf(errOut, "bruh, couldn't read %s: %v\n", filename, err)
+		return ExitRuntimeError
+	}
+	if len(p.Diagnostics()) != 0 {
+		lineAt := recent.Line
+		if seeker, ok := r.(io.ReadSeeker); ok {
+			if lines, err := reread(seeker, p.Diagnostics()); err == nil {
+				lineAt = func(n int) (string, bool) {
+					line, ok := lines[n]
+					return line, ok
+				}
+			}
+		}
+		for _, d := range p.Diagnostics() {
+			diagnostic.RenderFrom(errOut, lineAt, d)
+		}
+		return ExitParseError
+	}
+
+	evaluated := evaluator.Eval(program, env)
+	if evaluated == nil {
+		return ExitOK
+	}
+	if evaluated.Type() == object.ERROR_OBJ {
+		io.WriteString(errOut, evaluated.Inspect())
+		io.WriteString(errOut, "\n")
+		return ExitRuntimeError
+	}
+	if evaluated != evaluator.NULL || showNull {
+		io.WriteString(out, evaluated.Inspect())
+		io.WriteString(out, "\n")
+	}
+	return ExitOK
+}
+
+// keepLines is how many of the most recent lines RunReader holds on to.
+const keepLines = 64
+
+// recentLines is a writer that remembers only the last few lines written to
+// it, numbered from 1.
+type recentLines struct {
+	keep    int
+	first   int      // line number of lines[0]
+	lines   []string // complete lines, without their newline
+	partial []byte   // the line still being written
+}
+
+func newRecentLines(keep int) *recentLines {
+	return &recentLines{keep: keep, first: 1}
+}
+
+func (rl *recentLines) Write(b []byte) (int, error) {
+	n := len(b)
+	for {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			rl.partial = append(rl.partial, b...)
+			return n, nil
+		}
+		rl.lines = append(rl.lines, string(rl.partial)+string(b[:i]))
+		rl.partial = rl.partial[:0]
+		b = b[i+1:]
+		if len(rl.lines) > rl.keep {
+			drop := len(rl.lines) - rl.keep
+			rl.lines = append(rl.lines[:0], rl.lines[drop:]...)
+			rl.first += drop
+		}
+	}
+}
+
+// Line returns line n if it is still held.
+func (rl *recentLines) Line(n int) (string, bool) {
+	switch {
+	case n >= rl.first && n < rl.first+len(rl.lines):
+		return rl.lines[n-rl.first], true
+	case n == rl.first+len(rl.lines):
+		return string(rl.partial), true
+	}
+	return "", false
+}
+
+// reread goes back to the start of r and collects just the lines the
+// diagnostics point at.
+func reread(r io.ReadSeeker, diagnostics []diagnostic.Diagnostic) (map[int]string, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	wanted := make(map[int]bool)
+	last := 0
+	for _, d := range diagnostics {
+		wanted[d.Span.Start.Line] = true
+		if d.Span.Start.Line > last {
+			last = d.Span.Start.Line
+		}
+	}
+
+	lines := make(map[int]string)
+	br := bufio.NewReader(r)
+	for n := 1; n <= last; n++ {
+		line, err := br.ReadString('\n')
+		if wanted[n] {
+			lines[n] = strings.TrimSuffix(line, "\n")
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,165 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/Jitesh117/brainrotLang-interpreter/object"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedCode   int
+		expectedOut    string
+		expectedErrOut string
+	}{
+		{"yeet x = 5;\nyeet y = x * 2;\ny + 1", ExitOK, "11\n", ""},
+		{"yeet x = 5;", ExitOK, "", ""},
+		{"fr (cap) { 1 }", ExitOK, "", ""},
+		{"yeet x = 5;\nx + based", ExitRuntimeError, "",
+			"ERROR: main.br:2:3: L + ratio + type mismatch: INTEGER + BOOLEAN\n"},
+		{"yeet x 5;", ExitParseError, "",
+			"error[E0001]: expected next token to be =, got INT instead\n" +
+				" --> main.br:1:8\n" +
+				"  |\n" +
+				"1 | yeet x 5;\n" +
+				"  |        ^\n"},
+	}
+
+	for _, tt := range tests {
+		var out, errOut bytes.Buffer
+		code := Run("main.br", tt.input, object.NewEnvironment(), &out, &errOut)
+		if code != tt.expectedCode {
+			t.Errorf("wrong exit code for %q. expected=%d, got=%d", tt.input, tt.expectedCode, code)
+		}
+		if out.String() != tt.expectedOut {
+			t.Errorf("wrong output for %q. expected=%q, got=%q", tt.input, tt.expectedOut, out.String())
+		}
+		if errOut.String() != tt.expectedErrOut {
+			t.Errorf("wrong error output for %q. expected=%q, got=%q", tt.input, tt.expectedErrOut, errOut.String())
+		}
+	}
+}
+
+func TestRunReader(t *testing.T) {
+	tests := []struct {
+		reader         io.Reader
+		expectedCode   int
+		expectedOut    string
+		expectedErrOut string
+	}{
+		{iotest.OneByteReader(strings.NewReader("yeet x = 0x10;\nx * 2")), ExitOK, "32\n", ""},
+		{iotest.HalfReader(strings.NewReader("yeet x = 5;\nyeet y 6;")), ExitParseError, "",
+			"error[E0001]: expected next token to be =, got INT instead\n" +
+				" --> main.br:2:8\n" +
+				"  |\n" +
+				"2 | yeet y 6;\n" +
+				"  |        ^\n"},
+		{io.MultiReader(strings.NewReader("yeet x = 5;"), iotest.ErrReader(errors.New("disk is on fire"))),
+			ExitRuntimeError, "", "bruh, couldn't read main.br: disk is on fire\n"},
+	}
+
+	for i, tt := range tests {
+		var out, errOut bytes.Buffer
+		code := RunReader("main.br", tt.reader, object.NewEnvironment(), &out, &errOut)
+		if code != tt.expectedCode {
+			t.Errorf("tests[%d] - wrong exit code. expected=%d, got=%d", i, tt.expectedCode, code)
+		}
+		if out.String() != tt.expectedOut {
+			t.Errorf("tests[%d] - wrong output. expected=%q, got=%q", i, tt.expectedOut, out.String())
+		}
+		if errOut.String() != tt.expectedErrOut {
+			t.Errorf("tests[%d] - wrong error output. expected=%q, got=%q", i, tt.expectedErrOut, errOut.String())
+		}
+	}
+}
+
+func TestRunReaderKeepsOnlyRecentLines(t *testing.T) {
+	program := "yeet y 6;\n" + strings.Repeat("yeet x = 1;\n", 10000) + "yeet z 7;"
+	first := "error[E0001]: expected next token to be =, got INT instead\n" +
+		" --> main.br:1:8\n"
+	firstSource := "  |\n" +
+		"1 | yeet y 6;\n" +
+		"  |        ^\n"
+	last := "error[E0001]: expected next token to be =, got INT instead\n" +
+		"     --> main.br:10002:8\n" +
+		"      |\n" +
+		"10002 | yeet z 7;\n" +
+		"      |        ^\n"
+
+	tests := []struct {
+		name     string
+		reader   io.Reader
+		expected string
+	}{
+		// A pipe can't be read twice, so only the recent lines are there
+		{"pipe", struct{ io.Reader }{strings.NewReader(program)}, first + last},
+		// A file can, so the early line is read again
+		{"file", strings.NewReader(program), first + firstSource + last},
+	}
+
+	for _, tt := range tests {
+		var out, errOut bytes.Buffer
+		RunReader("main.br", tt.reader, object.NewEnvironment(), &out, &errOut)
+		if errOut.String() != tt.expected {
+			t.Errorf("%s - wrong error output. expected=%q, got=%q", tt.name, tt.expected, errOut.String())
+		}
+	}
+}
+
+func TestRecentLines(t *testing.T) {
+	rl := newRecentLines(keepLines)
+	for i := 1; i <= 10000; i++ {
+		fmt.Fprintf(rl, "line %d\n", i)
+	}
+	io.WriteString(rl, "tail")
+
+	if len(rl.lines) != keepLines {
+		t.Errorf("wrong number of lines kept. expected=%d, got=%d", keepLines, len(rl.lines))
+	}
+	if _, ok := rl.Line(1); ok {
+		t.Errorf("line 1 is still held")
+	}
+	if line, ok := rl.Line(10000); !ok || line != "line 10000" {
+		t.Errorf("wrong line 10000. got=%q, %t", line, ok)
+	}
+	if line, ok := rl.Line(10001); !ok || line != "tail" {
+		t.Errorf("wrong last line. got=%q, %t", line, ok)
+	}
+}
+
+func TestRunInteractive(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedCode int
+		expectedOut  string
+	}{
+		{"yeet x = 5; x * 2", ExitOK, "10\n"},
+		{"yeet x = 5;", ExitOK, ""},
+		{"fr (cap) { 1 }", ExitOK, "null\n"},
+		{"1 + based", ExitRuntimeError, "ERROR: 1:3: L + ratio + type mismatch: INTEGER + BOOLEAN\n"},
+		{"yeet x 5;", ExitParseError,
+			"error[E0001]: expected next token to be =, got INT instead\n" +
+				" --> 1:8\n" +
+				"  |\n" +
+				"1 | yeet x 5;\n" +
+				"  |        ^\n"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		code := RunInteractive(tt.input, object.NewEnvironment(), &out)
+		if code != tt.expectedCode {
+			t.Errorf("wrong exit code for %q. expected=%d, got=%d", tt.input, tt.expectedCode, code)
+		}
+		if out.String() != tt.expectedOut {
+			t.Errorf("wrong output for %q. expected=%q, got=%q", tt.input, tt.expectedOut, out.String())
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package token
 
+import "fmt"
+
 type TokenType string
 
 const (
@@ -8,7 +10,15 @@ const (
 	// Identifiers + literals
 	IDENT  = "IDENT"  // add, foobar, x, y, ...
 	INT    = "INT"    // 1343456
+	FLOAT  = "FLOAT"  // 3.14, 1e-3
 	STRING = "STRING" // "foobar"
+	// An f-string like f"a{x}b{y}c" is lexed as FSTRING_HEAD "a", the
+	// tokens of x, FSTRING_MIDDLE "b", the tokens of y, FSTRING_TAIL "c"
+	FSTRING_HEAD   = "FSTRING_HEAD"
+	FSTRING_MIDDLE = "FSTRING_MIDDLE"
+	FSTRING_TAIL   = "FSTRING_TAIL"
+	// Comments, only emitted when the lexer is asked to keep them
+	COMMENT = "COMMENT" // // foobar or /* foobar */
 	// Operators
 	ASSIGN   = "="
 	PLUS     = "+"
@@ -16,12 +26,33 @@ const (
 	BANG     = "!"
 	ASTERISK = "*"
 	SLASH    = "/"
+	PERCENT  = "%"
+	POWER    = "**"
 	LT       = "<"
 	GT       = ">"
+	LT_EQ    = "<="
+	GT_EQ    = ">="
 	EQ       = "=="
 	NOT_EQ   = "!="
+	AND      = "&&"
+	OR       = "||"
+
+	BIT_AND     = "&"
+	BIT_OR      = "|"
+	BIT_XOR     = "^"
+	SHIFT_LEFT  = "<<"
+	SHIFT_RIGHT = ">>"
+
+	PLUS_ASSIGN     = "+="
+	MINUS_ASSIGN    = "-="
+	ASTERISK_ASSIGN = "*="
+	SLASH_ASSIGN    = "/="
+	PERCENT_ASSIGN  = "%="
+	INCREMENT       = "++"
+	DECREMENT       = "--"
 	// Delimiters
 	COMMA     = ","
+	ELLIPSIS  = "..."
 	SEMICOLON = ";"
 	COLON     = ":"
 	LPAREN    = "("
@@ -38,11 +69,44 @@ const (
 	IF       = "IF"
 	ELSE     = "ELSE"
 	RETURN   = "RETURN"
+	WHILE    = "WHILE"
+	BREAK    = "BREAK"
+	CONTINUE = "CONTINUE"
+	IN       = "IN"
 )
 
+// Position is a location in the source: an optional filename plus a
+// 1-based line and column. Offset is the 0-based byte offset.
+type Position struct {
+	Filename string
+	Offset   int
+	Line     int
+	Column   int
+}
+
+// IsValid reports whether the position has been set by the lexer.
+func (p Position) IsValid() bool { return p.Line > 0 }
+
+// String returns the position as "file:line:column", "line:column" when
+// there is no filename, or "-" when the position is not valid.
+func (p Position) String() string {
+	if !p.IsValid() {
+		if p.Filename != "" {
+			return p.Filename
+		}
+		return "-"
+	}
+	if p.Filename != "" {
+		return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
 type Token struct {
 	Type    TokenType
 	Literal string
+	Pos     Position
+	End     Position // just past the token in the source
 }
 
 var keywords = map[string]TokenType{
@@ -53,6 +117,10 @@ var keywords = map[string]TokenType{
 	"fr":    IF,
 	"sus":   ELSE,
 	"slay":  RETURN,
+	"grind": WHILE,
+	"ghost": BREAK,
+	"nvm":   CONTINUE,
+	"in":    IN,
 }
 
 func LookupIdent(ident string) TokenType {